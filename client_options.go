@@ -0,0 +1,128 @@
+package container
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Container created by [NewContainer].
+type Option func(*Container) error
+
+// WithHost sets the docker host URL to connect to, overriding the
+// DOCKER_HOST (EnvOverrideHost) environment variable.
+// See [NewContainer] for the supported URL schemes.
+func WithHost(host string) Option {
+	return func(c *Container) error {
+		if _, _, err := parseHost(host); err != nil {
+			return err
+		}
+		c.host = host
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests to the daemon.
+// The client's transport is responsible for connecting to the daemon;
+// requests are addressed to http://localhost and the dial, TLS and pooling
+// options have no effect on it. This is mainly useful for injecting a
+// transport that talks to a fake daemon in tests.
+//
+// Unless WithHost is also given, the DOCKER_HOST, DOCKER_CERT_PATH and
+// DOCKER_TLS_VERIFY environment variables are ignored.
+//
+// The client is not used by ContainerAttach and ExecAttach, which take over
+// the connection and therefore always dial the docker host given by WithHost
+// directly, using the dial timeout and TLS configuration. Without WithHost,
+// they return an error.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Container) error {
+		if client == nil {
+			return errors.New("http client cannot be nil")
+		}
+		c.client = client
+		return nil
+	}
+}
+
+// WithDialTimeout sets the maximum amount of time to wait for a connection
+// to the daemon to be established. The default is 10 seconds.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *Container) error {
+		c.dialTimeout = timeout
+		return nil
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle (keep-alive) connections
+// to keep open to the daemon. The default is 10.
+func WithMaxIdleConns(n int) Option {
+	return func(c *Container) error {
+		if n < 0 {
+			return fmt.Errorf("max idle connections cannot be negative: %d", n)
+		}
+		c.maxIdleConns = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets the maximum amount of time an idle connection
+// remains open before closing itself. The default is 30 seconds.
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(c *Container) error {
+		c.idleConnTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for tcp:// hosts,
+// overriding the DOCKER_CERT_PATH and DOCKER_TLS_VERIFY environment variables.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Container) error {
+		if config == nil {
+			return errors.New("tls config cannot be nil")
+		}
+		c.tlsConfig = config
+		return nil
+	}
+}
+
+// WithTLSClientConfig configures mutual TLS for tcp:// hosts using the CA
+// certificate in caFile and the client key pair in certFile and keyFile,
+// overriding the DOCKER_CERT_PATH and DOCKER_TLS_VERIFY environment variables.
+func WithTLSClientConfig(caFile, certFile, keyFile string) Option {
+	return func(c *Container) error {
+		config, err := loadTLSConfig(caFile, certFile, keyFile, false)
+		if err != nil {
+			return err
+		}
+		c.tlsConfig = config
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Container) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithAPIVersion pins the API version, e.g. "1.47", used to prefix the
+// path of each request sent to the daemon. This disables API version
+// negotiation; the daemon rejects requests for versions it does not support.
+func WithAPIVersion(version string) Option {
+	return func(c *Container) error {
+		version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+		if !validVersion(version) {
+			return fmt.Errorf("invalid API version %q", version)
+		}
+		c.version = version
+		c.negotiated = true
+		return nil
+	}
+}
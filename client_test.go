@@ -0,0 +1,431 @@
+package container_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/relab/container"
+)
+
+// newFakeDaemon starts an HTTP server that answers /_ping requests on the
+// given listener, standing in for the docker daemon. All other requests are
+// passed to handler, or answered with 404 Not Found if handler is nil.
+func newFakeDaemon(t *testing.T, l net.Listener, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_ping":
+			_, _ = w.Write([]byte("OK"))
+		case handler != nil:
+			handler(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	if l != nil {
+		_ = srv.Listener.Close()
+		srv.Listener = l
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewContainerDockerHost(t *testing.T) {
+	srv := newFakeDaemon(t, nil, nil)
+	sock := filepath.Join(t.TempDir(), "docker.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newFakeDaemon(t, l, nil)
+
+	tests := []struct {
+		name string
+		host string
+	}{
+		{name: "tcp", host: "tcp://" + srv.Listener.Addr().String()},
+		{name: "http", host: srv.URL},
+		{name: "unix", host: "unix://" + sock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(container.EnvOverrideHost, tt.host)
+			c, err := container.NewContainer()
+			if err != nil {
+				t.Fatalf("Failed to create container client: %v", err)
+			}
+			if _, err := c.Ping(t.Context()); err != nil {
+				t.Fatalf("Failed to ping fake daemon at %s: %v", tt.host, err)
+			}
+		})
+	}
+}
+
+func TestNewContainerInvalidDockerHost(t *testing.T) {
+	for _, host := range []string{
+		"localhost:2375",
+		"ssh://user@host",
+		"tcp://",
+		"tcp://localhost:",
+		"tcp://localhost:2375/path",
+		"unix://",
+		"npipe:////./pipe/docker_engine",
+	} {
+		t.Run(host, func(t *testing.T) {
+			t.Setenv(container.EnvOverrideHost, host)
+			if _, err := container.NewContainer(); err == nil {
+				t.Errorf("NewContainer() with DOCKER_HOST=%q: expected error", host)
+			}
+		})
+	}
+}
+
+func TestNewContainerDefaultPort(t *testing.T) {
+	t.Run("PlainText", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:2375")
+		if err != nil {
+			t.Skipf("Port 2375 is not available: %v", err)
+		}
+		newFakeDaemon(t, l, nil)
+		c, err := container.NewContainer(container.WithHost("tcp://127.0.0.1"))
+		if err != nil {
+			t.Fatalf("Failed to create container client: %v", err)
+		}
+		if _, err := c.Ping(t.Context()); err != nil {
+			t.Fatalf("Failed to ping fake daemon on port 2375: %v", err)
+		}
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:2376")
+		if err != nil {
+			t.Skipf("Port 2376 is not available: %v", err)
+		}
+		t.Cleanup(func() { _ = l.Close() })
+		accepted := make(chan struct{})
+		go func() {
+			if conn, err := l.Accept(); err == nil {
+				close(accepted)
+				_ = conn.Close()
+			}
+		}()
+		c, err := container.NewContainer(container.WithHost("tcp://127.0.0.1"), container.WithTLSConfig(&tls.Config{}))
+		if err != nil {
+			t.Fatalf("Failed to create container client: %v", err)
+		}
+		_, _ = c.Ping(t.Context()) // the handshake fails; only the connection matters
+		select {
+		case <-accepted:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Ping with TLS did not connect to port 2376")
+		}
+	})
+}
+
+func TestNewContainerOptions(t *testing.T) {
+	var gotPath, gotUserAgent string
+	srv := newVersionedFakeDaemon(t, "1.50", func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotUserAgent = r.URL.Path, r.UserAgent()
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	// DOCKER_HOST is ignored when WithHost is given.
+	t.Setenv(container.EnvOverrideHost, "invalid")
+	c, err := container.NewContainer(
+		container.WithHost(srv.URL),
+		container.WithUserAgent("container-test/1.0"),
+		container.WithAPIVersion("v1.47"),
+		container.WithDialTimeout(time.Second),
+		container.WithMaxIdleConns(50),
+		container.WithIdleConnTimeout(time.Minute),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create container client: %v", err)
+	}
+	if _, err := c.ContainerInspect(t.Context(), "abc"); err != nil {
+		t.Fatalf("Failed to inspect container: %v", err)
+	}
+	if want := "/v1.47/containers/abc/json"; gotPath != want {
+		t.Errorf("request path = %q, want %q", gotPath, want)
+	}
+	if want := "container-test/1.0"; gotUserAgent != want {
+		t.Errorf("User-Agent = %q, want %q", gotUserAgent, want)
+	}
+}
+
+func TestAPIVersionNegotiation(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion string
+		opts          []container.Option
+		wantVersion   string
+	}{
+		{name: "OlderDaemon", serverVersion: "1.43", wantVersion: "1.43"},
+		{name: "NewerDaemon", serverVersion: "1.99", wantVersion: container.DefaultAPIVersion},
+		{name: "UnversionedDaemon", serverVersion: "", wantVersion: "1.24"},
+		{name: "Pinned", serverVersion: "1.43", opts: []container.Option{container.WithAPIVersion("1.45")}, wantVersion: "1.45"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			srv := newVersionedFakeDaemon(t, tt.serverVersion, func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_, _ = w.Write([]byte("{}"))
+			})
+			c, err := container.NewContainer(append(tt.opts, container.WithHost(srv.URL))...)
+			if err != nil {
+				t.Fatalf("Failed to create container client: %v", err)
+			}
+			if _, err := c.ContainerInspect(t.Context(), "abc"); err != nil {
+				t.Fatalf("Failed to inspect container: %v", err)
+			}
+			if got := c.ClientVersion(); got != tt.wantVersion {
+				t.Errorf("ClientVersion() = %q, want %q", got, tt.wantVersion)
+			}
+			if want := "/v" + tt.wantVersion + "/containers/abc/json"; gotPath != want {
+				t.Errorf("request path = %q, want %q", gotPath, want)
+			}
+		})
+	}
+}
+
+// newVersionedFakeDaemon starts an HTTP server that answers /_ping requests
+// with the given API version and passes all other requests to handler.
+func newVersionedFakeDaemon(t *testing.T, version string, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_ping" {
+			if version != "" {
+				w.Header().Set("API-Version", version)
+			}
+			w.Header().Set("OSType", "linux")
+			_, _ = w.Write([]byte("OK"))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewContainerWithHTTPClient(t *testing.T) {
+	var gotURL string
+	client := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			gotURL = r.URL.String()
+			rec := httptest.NewRecorder()
+			rec.WriteString("OK")
+			return rec.Result(), nil
+		}),
+	}
+	// The environment is ignored for an injected client.
+	t.Setenv(container.EnvOverrideHost, "ssh://user@host")
+	t.Setenv(container.EnvOverrideCertPath, t.TempDir())
+	t.Setenv(container.EnvTLSVerify, "1")
+	c, err := container.NewContainer(container.WithHTTPClient(client))
+	if err != nil {
+		t.Fatalf("Failed to create container client: %v", err)
+	}
+	if _, err := c.Ping(t.Context()); err != nil {
+		t.Fatalf("Failed to ping fake transport: %v", err)
+	}
+	if want := "http://localhost/_ping"; gotURL != want {
+		t.Errorf("request URL = %q, want %q", gotURL, want)
+	}
+	// Attach requests bypass the client and need a host to dial.
+	if _, err := c.ContainerAttach(t.Context(), "abc", container.AttachOptions{}); err == nil {
+		t.Error("ContainerAttach() without host: expected error")
+	}
+}
+
+func TestNewContainerInvalidOptions(t *testing.T) {
+	tests := map[string]container.Option{
+		"Host":         container.WithHost("ssh://user@host"),
+		"HTTPClient":   container.WithHTTPClient(nil),
+		"MaxIdleConns": container.WithMaxIdleConns(-1),
+		"APIVersion":   container.WithAPIVersion("latest"),
+		"TLSConfig":    container.WithTLSConfig(nil),
+		"TLSFiles":     container.WithTLSClientConfig("ca.pem", "cert.pem", "key.pem"),
+	}
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := container.NewContainer(opt); err == nil {
+				t.Errorf("NewContainer(With%s(...)): expected error", name)
+			}
+		})
+	}
+}
+
+// newTestClient returns a client for a fake daemon with API version 1.47
+// that passes all requests except /_ping to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *container.Container {
+	t.Helper()
+	srv := newVersionedFakeDaemon(t, "1.47", handler)
+	c, err := container.NewContainer(container.WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("Failed to create container client: %v", err)
+	}
+	return c
+}
+
+// newTestClientForHost returns a client for the docker host whose requests
+// are answered in-process as by newTestClient, without connecting to the host.
+func newTestClientForHost(t *testing.T, host string, handler http.HandlerFunc) *container.Container {
+	t.Helper()
+	client := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			if r.URL.Path == "/_ping" {
+				rec.Header().Set("API-Version", "1.47")
+				rec.WriteString("OK")
+			} else {
+				handler(rec, r)
+			}
+			return rec.Result(), nil
+		}),
+	}
+	c, err := container.NewContainer(container.WithHost(host), container.WithHTTPClient(client))
+	if err != nil {
+		t.Fatalf("Failed to create container client: %v", err)
+	}
+	return c
+}
+
+// respondWith returns a handler that responds to all requests with body.
+func respondWith(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewContainerTLS(t *testing.T) {
+	clientCert, clientKey := newClientCertificate(t)
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(clientCert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientPool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	certPath := t.TempDir()
+	writePEM(t, filepath.Join(certPath, "ca.pem"), "CERTIFICATE", srv.Certificate().Raw)
+	writePEM(t, filepath.Join(certPath, "cert.pem"), "CERTIFICATE", clientCert.Raw)
+	keyDER, err := x509.MarshalECPrivateKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(certPath, "key.pem"), "EC PRIVATE KEY", keyDER)
+
+	t.Setenv(container.EnvOverrideHost, "tcp://"+srv.Listener.Addr().String())
+
+	t.Run("MutualTLS", func(t *testing.T) {
+		t.Setenv(container.EnvOverrideCertPath, certPath)
+		t.Setenv(container.EnvTLSVerify, "1")
+		c, err := container.NewContainer()
+		if err != nil {
+			t.Fatalf("Failed to create container client: %v", err)
+		}
+		if _, err := c.Ping(t.Context()); err != nil {
+			t.Fatalf("Failed to ping TLS daemon: %v", err)
+		}
+	})
+
+	t.Run("PlainText", func(t *testing.T) {
+		t.Setenv(container.EnvOverrideCertPath, "")
+		t.Setenv(container.EnvTLSVerify, "")
+		c, err := container.NewContainer()
+		if err != nil {
+			t.Fatalf("Failed to create container client: %v", err)
+		}
+		if _, err := c.Ping(t.Context()); err == nil {
+			t.Fatal("Ping without TLS configuration succeeded; expected error")
+		}
+	})
+
+	t.Run("UnixIgnoresTLSEnvironment", func(t *testing.T) {
+		sock := filepath.Join(t.TempDir(), "docker.sock")
+		l, err := net.Listen("unix", sock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newFakeDaemon(t, l, nil)
+		t.Setenv(container.EnvOverrideHost, "unix://"+sock)
+		t.Setenv(container.EnvOverrideCertPath, t.TempDir())
+		t.Setenv(container.EnvTLSVerify, "1")
+		c, err := container.NewContainer()
+		if err != nil {
+			t.Fatalf("Failed to create container client: %v", err)
+		}
+		if _, err := c.Ping(t.Context()); err != nil {
+			t.Fatalf("Failed to ping unix daemon: %v", err)
+		}
+	})
+
+	t.Run("MissingCertificates", func(t *testing.T) {
+		t.Setenv(container.EnvOverrideCertPath, t.TempDir())
+		t.Setenv(container.EnvTLSVerify, "1")
+		if _, err := container.NewContainer(); err == nil {
+			t.Fatal("NewContainer() with empty certificate directory succeeded; expected error")
+		}
+	})
+}
+
+// newClientCertificate returns a self-signed certificate for TLS client authentication.
+func newClientCertificate(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "container-test-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,5 +1,7 @@
 package container
 
+import "time"
+
 // The struct definitions in this file is largely copied from the Docker API
 // types, but may have been simplified for our use case.
 // See the original [Config].
@@ -16,11 +18,47 @@ package container
 //
 // [Config]: https://pkg.go.dev/github.com/docker/docker/api/types/container#Config
 type Config struct {
-	User         string   // User that will run the command(s) inside the container, also support user:group
-	ExposedPorts PortSet  `json:",omitempty"` // List of exposed ports
-	Env          []string // List of environment variable to set in the container
-	Cmd          []string // Command to run when starting the container
-	Image        string   // Name of the image as it was passed by the operator (e.g. could be symbolic)
+	Hostname     string            // Hostname
+	Domainname   string            // Domainname
+	User         string            // User that will run the command(s) inside the container, also support user:group
+	AttachStdin  bool              // Attach the standard input, makes possible user interaction
+	AttachStdout bool              // Attach the standard output
+	AttachStderr bool              // Attach the standard error
+	ExposedPorts PortSet           `json:",omitempty"` // List of exposed ports
+	Tty          bool              // Attach standard streams to a tty, including stdin if it is not closed.
+	OpenStdin    bool              // Open stdin
+	StdinOnce    bool              // If true, close stdin after the 1 attached client disconnects.
+	Env          []string          // List of environment variable to set in the container
+	Cmd          []string          // Command to run when starting the container
+	Healthcheck  *HealthConfig     `json:",omitempty"` // Healthcheck describes how to check the container is healthy
+	Image        string            // Name of the image as it was passed by the operator (e.g. could be symbolic)
+	WorkingDir   string            // Current directory (PWD) in the command will be launched
+	Entrypoint   []string          // Entrypoint to run when starting the container; use []string{""} to reset the image's entrypoint
+	Labels       map[string]string // List of labels set to this container
+	StopSignal   string            `json:",omitempty"` // Signal to stop a container
+	StopTimeout  *int              `json:",omitempty"` // Timeout (in seconds) to stop a container
+}
+
+// HealthConfig holds configuration settings for the HEALTHCHECK feature.
+type HealthConfig struct {
+	// Test is the test to perform to check that the container is healthy.
+	// An empty slice means to inherit the default.
+	// The options are:
+	// {} : inherit healthcheck
+	// {"NONE"} : disable healthcheck
+	// {"CMD", args...} : exec arguments directly
+	// {"CMD-SHELL", command} : run command with system's default shell
+	Test []string `json:",omitempty"`
+
+	// Zero means to inherit. Durations are expressed as integer nanoseconds.
+	Interval      time.Duration `json:",omitempty"` // Interval is the time to wait between checks.
+	Timeout       time.Duration `json:",omitempty"` // Timeout is the time to wait before considering the check to have hung.
+	StartPeriod   time.Duration `json:",omitempty"` // The start period for the container to initialize before the retries starts to count down.
+	StartInterval time.Duration `json:",omitempty"` // The interval to attempt healthchecks at during the start period
+
+	// Retries is the number of consecutive failures needed to consider a container as unhealthy.
+	// Zero means inherit.
+	Retries int `json:",omitempty"`
 }
 
 // PortBinding represents a binding between a Host IP address and a Host Port
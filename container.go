@@ -3,14 +3,18 @@ package container
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/relab/container/build"
@@ -19,51 +23,126 @@ import (
 )
 
 const (
-	defaultTimeout = 10 * time.Second
+	defaultTimeout         = 10 * time.Second
+	defaultMaxIdleConns    = 10
+	defaultIdleConnTimeout = 30 * time.Second
 )
 
 type Container struct {
-	client *http.Client
+	client    *http.Client
+	host      string      // docker host URL, e.g. "unix:///var/run/docker.sock"
+	network   string      // network to dial, e.g. "unix" or "tcp"
+	addr      string      // address to dial, e.g. a socket path or "host:port"
+	tlsConfig *tls.Config // TLS configuration for tcp connections; nil means plain text
+	userAgent string      // User-Agent header sent with each request; empty means Go's default
+
+	mu         sync.Mutex
+	version    string // API version used to prefix request paths
+	negotiated bool   // true if version is pinned or has been negotiated with the daemon
+
+	dialTimeout     time.Duration
+	maxIdleConns    int
+	idleConnTimeout time.Duration
 }
 
 // NewContainer creates a new Container instance that can be used to interact with the Docker daemon.
-// Currently we support only the default Docker host URL.
-func NewContainer() (*Container, error) {
-	u, err := url.Parse(DefaultDockerHost)
+// The daemon is located using the DOCKER_HOST (EnvOverrideHost) environment variable,
+// falling back to DefaultDockerHost if it is unset or empty. Use [WithHost] to override it.
+// Supported hosts are unix:// sockets and tcp:// or http:// addresses, e.g.
+// "unix://$XDG_RUNTIME_DIR/docker.sock" for a rootless daemon or "tcp://127.0.0.1:2375".
+// A tcp:// host without a port uses port 2376 with TLS and 2375 without.
+//
+// Connections to tcp:// hosts use mutual TLS if the DOCKER_CERT_PATH (EnvOverrideCertPath)
+// or DOCKER_TLS_VERIFY (EnvTLSVerify) environment variables are set, in which case
+// the CA certificate and client key pair are loaded from ca.pem, cert.pem and key.pem.
+// Use [WithTLSConfig] or [WithTLSClientConfig] to configure TLS explicitly.
+//
+// The remaining options can be used to tune the HTTP client used to talk to the daemon.
+func NewContainer(opts ...Option) (*Container, error) {
+	c := &Container{
+		version:         DefaultAPIVersion,
+		dialTimeout:     defaultTimeout,
+		maxIdleConns:    defaultMaxIdleConns,
+		idleConnTimeout: defaultIdleConnTimeout,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.host == "" && c.client != nil {
+		// The injected client connects to the daemon; the environment
+		// only matters for the dial used by attach requests.
+		return c, nil
+	}
+	if c.host == "" {
+		c.host = hostFromEnv()
+	}
+
+	var err error
+	c.network, c.addr, err = parseHost(c.host)
 	if err != nil {
 		return nil, err
 	}
-
-	return &Container{
-		client: &http.Client{
+	if c.tlsConfig == nil && c.network == "tcp" {
+		c.tlsConfig, err = tlsConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.network == "tcp" {
+		c.addr = withDefaultPort(c.addr, c.tlsConfig != nil)
+	}
+	if c.client == nil {
+		c.client = &http.Client{
 			Transport: &http.Transport{
-				MaxIdleConns:    10,
-				IdleConnTimeout: 30 * time.Second,
+				MaxIdleConns:        c.maxIdleConns,
+				MaxIdleConnsPerHost: c.maxIdleConns,
+				IdleConnTimeout:     c.idleConnTimeout,
 				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-					return net.DialTimeout(u.Scheme, u.Path, defaultTimeout)
+					return c.dial(ctx)
 				},
 			},
-		},
-	}, nil
+		}
+	}
+	return c, nil
+}
+
+// dial opens a new connection to the docker daemon.
+func (c *Container) dial(ctx context.Context) (net.Conn, error) {
+	if c.network == "" {
+		return nil, errors.New("no docker host to dial: use WithHost together with WithHTTPClient")
+	}
+	dialer := &net.Dialer{Timeout: c.dialTimeout}
+	if c.tlsConfig != nil && c.network == "tcp" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: c.tlsConfig}
+		return tlsDialer.DialContext(ctx, c.network, c.addr)
+	}
+	return dialer.DialContext(ctx, c.network, c.addr)
 }
 
 // Ping checks if the Docker daemon is reachable and responds to a ping request.
-func (c *Container) Ping(ctx context.Context) error {
+// Unless the API version was pinned with [WithAPIVersion], the response is also
+// used to negotiate the API version to use for subsequent requests.
+func (c *Container) Ping(ctx context.Context) (PingResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/_ping", nil)
 	if err != nil {
-		return err
+		return PingResponse{}, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return err
+		return PingResponse{}, err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status: %s", resp.Status)
+	if err := checkResponse(resp, "ping failed"); err != nil {
+		return PingResponse{}, err
 	}
-	return nil
+	ping := newPingResponse(resp.Header)
+	c.negotiate(ping.APIVersion)
+	return ping, nil
 }
 
 // ImagePull requests the docker host to pull an image from a remote registry.
@@ -76,10 +155,14 @@ func (c *Container) ImagePull(ctx context.Context, refStr string) (io.ReadCloser
 		return nil, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkResponse(resp, "image pull failed"); err != nil {
+		close(resp)
+		return nil, err
+	}
 	return resp.Body, nil
 }
 
@@ -93,13 +176,14 @@ func (c *Container) ImageBuild(ctx context.Context, buildCtx io.Reader, options
 	}
 	req.Header.Set("Content-Type", "application/x-tar")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("image build failed: %s", resp.Status)
+	if err := checkResponse(resp, "image build failed"); err != nil {
+		close(resp)
+		return nil, err
 	}
 	return resp.Body, nil
 }
@@ -115,14 +199,14 @@ func (c *Container) ImageRemove(ctx context.Context, imageID string, options ima
 		return nil, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusNoContent {
-		return nil, fmt.Errorf("image removal failed: %s", resp.Status)
+	if err := checkResponse(resp, "image removal failed"); err != nil {
+		return nil, err
 	}
 	var response []image.DeleteResponse
 	err = json.NewDecoder(resp.Body).Decode(&response)
@@ -141,14 +225,14 @@ func (c *Container) NetworkCreate(ctx context.Context, options network.CreateOpt
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return network.CreateResponse{}, err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusCreated {
-		return network.CreateResponse{}, fmt.Errorf("network creation failed: %s", resp.Status)
+	if err := checkResponse(resp, "network creation failed"); err != nil {
+		return network.CreateResponse{}, err
 	}
 	var response network.CreateResponse
 	err = json.NewDecoder(resp.Body).Decode(&response)
@@ -166,14 +250,14 @@ func (c *Container) NetworkRemove(ctx context.Context, networkID string) error {
 		return err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("network removal failed: %s", resp.Status)
+	if err := checkResponse(resp, "network removal failed"); err != nil {
+		return err
 	}
 	return nil
 }
@@ -202,14 +286,14 @@ func (c *Container) NetworkConnect(ctx context.Context, networkID, containerID s
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("network connect failed: %s", resp.Status)
+	if err := checkResponse(resp, "network connect failed"); err != nil {
+		return err
 	}
 	return nil
 }
@@ -238,21 +322,53 @@ func (c *Container) NetworkDisconnect(ctx context.Context, networkID, containerI
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("network disconnect failed: %s", resp.Status)
+	if err := checkResponse(resp, "network disconnect failed"); err != nil {
+		return err
 	}
 	return nil
 }
 
+// ContainerList returns the list of containers in the docker host.
+// By default, only running containers are returned; use options.All to include
+// stopped containers, and options.Filters to restrict the list.
+func (c *Container) ContainerList(ctx context.Context, options ListOptions) ([]Summary, error) {
+	u, err := options.url()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "container list failed"); err != nil {
+		return nil, err
+	}
+	var response []Summary
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
 // ContainerCreate creates a new container based on the given configuration.
 // It can be associated with a name, but it's not mandatory.
+// The host configuration is validated before the request is sent, so that
+// invalid combinations of options are reported with a descriptive error.
 func (c *Container) ContainerCreate(ctx context.Context, config *Config, hostConfig *HostConfig, networkingConfig *network.NetworkingConfig, containerName string) (CreateResponse, error) {
+	if err := hostConfig.validate(); err != nil {
+		return CreateResponse{}, err
+	}
 	body, err := encodeBody(CreateRequest{
 		Config:           config,
 		HostConfig:       hostConfig,
@@ -271,14 +387,14 @@ func (c *Container) ContainerCreate(ctx context.Context, config *Config, hostCon
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return CreateResponse{}, err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusCreated {
-		return CreateResponse{}, fmt.Errorf("container creation failed: %s", resp.Status)
+	if err := checkResponse(resp, "container creation failed"); err != nil {
+		return CreateResponse{}, err
 	}
 
 	var response CreateResponse
@@ -297,14 +413,14 @@ func (c *Container) ContainerRemove(ctx context.Context, containerID string, opt
 		return err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("container removal failed: %s", resp.Status)
+	if err := checkResponse(resp, "container removal failed"); err != nil {
+		return err
 	}
 	return nil
 }
@@ -320,14 +436,14 @@ func (c *Container) ContainerStart(ctx context.Context, containerID string) erro
 		return err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("container start failed: %s", resp.Status)
+	if err := checkResponse(resp, "container start failed"); err != nil {
+		return err
 	}
 	return nil
 }
@@ -339,25 +455,119 @@ func (c *Container) ContainerStart(ctx context.Context, containerID string) erro
 // If the timeout is nil, the container's StopTimeout value is used, if set,
 // otherwise the engine default. A negative timeout value can be specified,
 // meaning no timeout, i.e. no forceful termination is performed.
+//
+// Stopping a container that is already stopped is not an error.
 func (c *Container) ContainerStop(ctx context.Context, containerID string, options StopOptions) error {
 	containerID = strings.TrimSpace(containerID)
 	if containerID == "" {
 		return fmt.Errorf("container ID cannot be empty")
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, options.url(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, options.url(containerID, "stop"), nil)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer close(resp)
 
+	// The daemon responds with 304 Not Modified if the container is already stopped.
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if err := checkResponse(resp, "container stop failed"); err != nil {
+		return err
+	}
 	return nil
 }
 
+// ContainerRestart stops and starts a container again. The options are
+// interpreted as for [Container.ContainerStop].
+func (c *Container) ContainerRestart(ctx context.Context, containerID string, options StopOptions) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container ID cannot be empty")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, options.url(containerID, "restart"), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "container restart failed"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ContainerKill sends a signal to a running container, e.g. "SIGHUP" or "9".
+// If signal is empty, the daemon sends SIGKILL. Killing a container that is
+// not running returns an error for which [IsConflict] reports true.
+func (c *Container) ContainerKill(ctx context.Context, containerID, signal string) error {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return fmt.Errorf("container ID cannot be empty")
+	}
+	query := url.Values{}
+	if signal != "" {
+		query.Set("signal", signal)
+	}
+	u := url.URL{Scheme: "http", Host: "localhost", Path: "/containers/" + containerID + "/kill", RawQuery: query.Encode()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "container kill failed"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ContainerUpdate updates the resource limits of a container, which may be running.
+// Zero-valued limits in resources are left unchanged.
+func (c *Container) ContainerUpdate(ctx context.Context, containerID string, resources Resources) (UpdateResponse, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return UpdateResponse{}, fmt.Errorf("container ID cannot be empty")
+	}
+	body, err := encodeBody(resources)
+	if err != nil {
+		return UpdateResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/containers/"+containerID+"/update", body)
+	if err != nil {
+		return UpdateResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return UpdateResponse{}, err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "container update failed"); err != nil {
+		return UpdateResponse{}, err
+	}
+	var response UpdateResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
 const containerWaitErrorMsgLimit = 2 * 1024 // 2KiB
 
 // ContainerWait waits until the specified container is in a certain state
@@ -381,12 +591,16 @@ func (c *Container) ContainerWait(ctx context.Context, containerID string, condi
 		return WaitResponse{}, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return WaitResponse{}, err
 	}
 	defer close(resp)
 
+	if err := checkResponse(resp, "container wait failed"); err != nil {
+		return WaitResponse{}, err
+	}
+
 	var buf bytes.Buffer
 	stream := io.TeeReader(resp.Body, &buf)
 
@@ -414,14 +628,14 @@ func (c *Container) ContainerInspect(ctx context.Context, containerID string) (I
 		return InspectResponse{}, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return InspectResponse{}, err
 	}
 	defer close(resp)
 
-	if resp.StatusCode != http.StatusOK {
-		return InspectResponse{}, fmt.Errorf("container inspect failed: %s", resp.Status)
+	if err := checkResponse(resp, "container inspect failed"); err != nil {
+		return InspectResponse{}, err
 	}
 
 	var response InspectResponse
@@ -429,6 +643,94 @@ func (c *Container) ContainerInspect(ctx context.Context, containerID string) (I
 	return response, err
 }
 
+// PortEndpoint returns the host address and port at which the container's
+// port is published, e.g. after starting a container whose port is bound to an
+// ephemeral host port. Bindings to all interfaces (0.0.0.0 or ::) are resolved
+// to the address of the docker host: the loopback address if the daemon runs
+// on this machine, i.e. is reached over a unix socket, a named pipe or a
+// loopback tcp:// DOCKER_HOST, and otherwise the address of the same IP version
+// of the tcp:// DOCKER_HOST. Bindings to the loopback address of a remote
+// docker host are not reachable and are skipped.
+// IPv4 bindings are preferred when the port is published on both IPv4 and IPv6.
+func (c *Container) PortEndpoint(ctx context.Context, containerID string, port Port) (netip.AddrPort, error) {
+	insp, err := c.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	var bindings []PortBinding
+	if insp.NetworkSettings != nil {
+		bindings = insp.NetworkSettings.Ports[port]
+	}
+
+	var endpoint netip.AddrPort
+	var bindingErr error
+	for _, binding := range bindings {
+		hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16)
+		if err != nil || hostPort == 0 {
+			continue // not yet assigned, or a range that the daemon did not resolve
+		}
+		addr, err := c.bindingAddr(ctx, binding.HostIP)
+		if err != nil {
+			if bindingErr == nil {
+				bindingErr = err
+			}
+			continue
+		}
+		if !endpoint.IsValid() || addr.Is4() && !endpoint.Addr().Is4() {
+			endpoint = netip.AddrPortFrom(addr, uint16(hostPort))
+		}
+	}
+	if !endpoint.IsValid() {
+		if bindingErr != nil {
+			return netip.AddrPort{}, fmt.Errorf("container %s: port %s: %w", containerID, port, bindingErr)
+		}
+		return netip.AddrPort{}, fmt.Errorf("container %s: port %s is not published", containerID, port)
+	}
+	return endpoint, nil
+}
+
+// bindingAddr returns the address at which a port bound to hostIP can be
+// reached, substituting the docker host's address if hostIP is unspecified.
+func (c *Container) bindingAddr(ctx context.Context, hostIP string) (netip.Addr, error) {
+	addr := netip.IPv4Unspecified()
+	if hostIP != "" {
+		var err error
+		if addr, err = netip.ParseAddr(hostIP); err != nil {
+			return netip.Addr{}, fmt.Errorf("invalid host IP %q: %w", hostIP, err)
+		}
+		addr = addr.Unmap()
+	}
+
+	if !addr.IsLoopback() && !addr.IsUnspecified() {
+		return addr, nil
+	}
+
+	// The daemon runs on this machine if connected over a unix socket or
+	// named pipe or to a loopback address, so its loopback is ours.
+	local := c.network != "tcp"
+	if !local {
+		host, err := daemonHostAddr(ctx, c.addr, "ip")
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		if addr.IsLoopback() && !host.IsLoopback() {
+			return netip.Addr{}, fmt.Errorf("bound to %s on remote docker host %s, which is not reachable from this machine", addr, host)
+		}
+		local = host.IsLoopback()
+	}
+	switch {
+	case addr.IsLoopback():
+		return addr, nil
+	case local && addr.Is6():
+		return netip.IPv6Loopback(), nil
+	case local:
+		return netip.AddrFrom4([4]byte{127, 0, 0, 1}), nil
+	case addr.Is6():
+		return daemonHostAddr(ctx, c.addr, "ip6")
+	}
+	return daemonHostAddr(ctx, c.addr, "ip4")
+}
+
 // ContainerLogs returns the logs generated by a container in an io.ReadCloser.
 // It's up to the caller to close the stream.
 //
@@ -449,8 +751,7 @@ func (c *Container) ContainerInspect(ctx context.Context, containerID string) (I
 // SIZE1, SIZE2, SIZE3, and SIZE4 are four bytes of uint32 encoded as big endian.
 // This is the size of OUTPUT.
 //
-// You can use github.com/docker/docker/pkg/stdcopy.StdCopy to demultiplex this
-// stream.
+// You can use StdCopy to demultiplex this stream.
 func (c *Container) ContainerLogs(ctx context.Context, containerID string, options LogsOptions) (io.ReadCloser, error) {
 	containerID = strings.TrimSpace(containerID)
 	if containerID == "" {
@@ -461,13 +762,234 @@ func (c *Container) ContainerLogs(ctx context.Context, containerID string, optio
 		return nil, err
 	}
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, "container logs failed"); err != nil {
+		close(resp)
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ExecCreate creates a new exec instance that runs a command in a running container.
+// The command is not started until ExecStart is called with the returned exec ID.
+func (c *Container) ExecCreate(ctx context.Context, containerID string, options ExecOptions) (ExecCreateResponse, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return ExecCreateResponse{}, fmt.Errorf("container ID cannot be empty")
+	}
+	if len(options.Cmd) == 0 {
+		return ExecCreateResponse{}, fmt.Errorf("exec command cannot be empty")
+	}
+	body, err := encodeBody(options)
+	if err != nil {
+		return ExecCreateResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/containers/"+containerID+"/exec", body)
+	if err != nil {
+		return ExecCreateResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return ExecCreateResponse{}, err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "exec creation failed"); err != nil {
+		return ExecCreateResponse{}, err
+	}
+	var response ExecCreateResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// ExecStart starts an exec instance created by ExecCreate and returns its
+// output stream in an io.ReadCloser. It's up to the caller to close the stream.
+//
+// The stream ends when the command exits, unless options.Detach is set, in which
+// case the stream is empty and the command continues running in the background.
+// Use ExecInspect to retrieve the command's exit code.
+//
+// The stream is multiplexed unless options.Tty is set, in the format described
+// for ContainerLogs.
+func (c *Container) ExecStart(ctx context.Context, execID string, options ExecStartOptions) (io.ReadCloser, error) {
+	execID = strings.TrimSpace(execID)
+	if execID == "" {
+		return nil, fmt.Errorf("exec ID cannot be empty")
+	}
+	body, err := encodeBody(options)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/exec/"+execID+"/start", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkResponse(resp, "exec start failed"); err != nil {
+		close(resp)
+		return nil, err
+	}
 	return resp.Body, nil
 }
 
+// ExecInspect returns information about an exec instance, such as whether it
+// is still running and its exit code.
+func (c *Container) ExecInspect(ctx context.Context, execID string) (ExecInspect, error) {
+	execID = strings.TrimSpace(execID)
+	if execID == "" {
+		return ExecInspect{}, fmt.Errorf("exec ID cannot be empty")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/exec/"+execID+"/json", nil)
+	if err != nil {
+		return ExecInspect{}, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return ExecInspect{}, err
+	}
+	defer close(resp)
+
+	if err := checkResponse(resp, "exec inspect failed"); err != nil {
+		return ExecInspect{}, err
+	}
+	var response ExecInspect
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+const execPollInterval = 50 * time.Millisecond
+
+// Exec runs the command cmd in a running container and waits for it to complete.
+// It returns the command's captured standard output and standard error, and
+// its exit code. A non-zero exit code is not considered an error.
+func (c *Container) Exec(ctx context.Context, containerID string, cmd []string) (stdout, stderr []byte, exitCode int, err error) {
+	exec, err := c.ExecCreate(ctx, containerID, ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	stream, err := c.ExecStart(ctx, exec.ID, ExecStartOptions{})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	var outBuf, errBuf bytes.Buffer
+	if _, err := StdCopy(&outBuf, &errBuf, stream); err != nil {
+		return outBuf.Bytes(), errBuf.Bytes(), 0, fmt.Errorf("exec output: %w", err)
+	}
+
+	// The exec instance may briefly be reported as running after the stream ends.
+	for {
+		insp, err := c.ExecInspect(ctx, exec.ID)
+		if err != nil {
+			return outBuf.Bytes(), errBuf.Bytes(), 0, err
+		}
+		if !insp.Running {
+			return outBuf.Bytes(), errBuf.Bytes(), insp.ExitCode, nil
+		}
+		select {
+		case <-ctx.Done():
+			return outBuf.Bytes(), errBuf.Bytes(), 0, ctx.Err()
+		case <-time.After(execPollInterval):
+		}
+	}
+}
+
+// ContainerAttach attaches to the standard streams of a container and returns a
+// HijackedResponse holding the connection to the container. It's up to the caller
+// to close the connection.
+//
+// Data written to the connection is sent to the container's stdin, if
+// options.Stdin is set. Use CloseWrite to signal the end of the input.
+// The output read from the connection is multiplexed unless the container
+// was created with a TTY, in the format described for ContainerLogs.
+//
+// The connection is always dialed to the configured docker host; an HTTP
+// client set with WithHTTPClient is not used.
+func (c *Container) ContainerAttach(ctx context.Context, containerID string, options AttachOptions) (HijackedResponse, error) {
+	containerID = strings.TrimSpace(containerID)
+	if containerID == "" {
+		return HijackedResponse{}, fmt.Errorf("container ID cannot be empty")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, options.url(containerID), nil)
+	if err != nil {
+		return HijackedResponse{}, err
+	}
+	return c.hijack(req, "container attach failed")
+}
+
+// ExecAttach starts an exec instance created by ExecCreate and attaches to its
+// standard streams, returning a HijackedResponse holding the connection to the
+// command. It's up to the caller to close the connection.
+//
+// Data written to the connection is sent to the command's stdin, if the exec
+// instance was created with AttachStdin. Use CloseWrite to signal the end of
+// the input. The output read from the connection is multiplexed unless
+// options.Tty is set, in the format described for ContainerLogs.
+//
+// The connection is always dialed to the configured docker host; an HTTP
+// client set with WithHTTPClient is not used.
+func (c *Container) ExecAttach(ctx context.Context, execID string, options ExecStartOptions) (HijackedResponse, error) {
+	execID = strings.TrimSpace(execID)
+	if execID == "" {
+		return HijackedResponse{}, fmt.Errorf("exec ID cannot be empty")
+	}
+	if options.Detach {
+		return HijackedResponse{}, fmt.Errorf("cannot attach to a detached exec instance")
+	}
+	body, err := encodeBody(options)
+	if err != nil {
+		return HijackedResponse{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/exec/"+execID+"/start", body)
+	if err != nil {
+		return HijackedResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.hijack(req, "exec attach failed")
+}
+
+// do sends req to the docker daemon after preparing it with prepareRequest.
+func (c *Container) do(req *http.Request) (*http.Response, error) {
+	if err := c.prepareRequest(req); err != nil {
+		return nil, err
+	}
+	return c.client.Do(req)
+}
+
+// prepareRequest adds the configured User-Agent header to req and prefixes
+// the request path with the API version. The API version is negotiated with
+// the daemon before the first request, unless it is pinned.
+// Ping requests are sent to the unversioned endpoint.
+func (c *Container) prepareRequest(req *http.Request) error {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if req.URL.Path != "/_ping" {
+		version, err := c.negotiatedVersion(req.Context())
+		if err != nil {
+			return err
+		}
+		req.URL.Path = "/v" + version + req.URL.Path
+	}
+	return nil
+}
+
 func encodeBody(obj any) (*bytes.Buffer, error) {
 	if obj == nil {
 		return nil, nil
@@ -1,5 +1,7 @@
 package container
 
+import "github.com/relab/container/network"
+
 // The struct definitions in this file is largely copied from the Docker API
 // types, but may have been simplified for our use case.
 // See the original [InspectResponse].
@@ -13,6 +15,7 @@ type InspectResponse struct {
 	Created         string
 	Path            string
 	Args            []string
+	State           *State
 	Image           string
 	ResolvConfPath  string
 	HostnamePath    string
@@ -33,5 +36,12 @@ type InspectResponse struct {
 
 // NetworkSettings exposes the network settings in the api
 type NetworkSettings struct {
-	Ports PortMap // Ports is a collection of PortBinding indexed by Port
+	Bridge     string  // Bridge is the Bridge name the network uses(e.g. `docker0`)
+	SandboxID  string  // SandboxID uniquely represents a container's network stack
+	SandboxKey string  // SandboxKey identifies the sandbox
+	Ports      PortMap // Ports is a collection of PortBinding indexed by Port
+
+	// Networks holds the endpoint settings of each network the container
+	// is connected to, indexed by network name.
+	Networks map[string]*network.EndpointSettings
 }
@@ -0,0 +1,203 @@
+package container_test
+
+import (
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relab/container"
+	"github.com/relab/container/network"
+)
+
+const inspectJSON = `{
+	"Id": "8dfafdbc3a40",
+	"Name": "/replica-1",
+	"State": {
+		"Status": "running",
+		"Running": true,
+		"Paused": false,
+		"Restarting": false,
+		"OOMKilled": false,
+		"Dead": false,
+		"Pid": 4242,
+		"ExitCode": 0,
+		"Error": "",
+		"StartedAt": "2025-10-17T12:00:00.123456789Z",
+		"FinishedAt": "0001-01-01T00:00:00Z",
+		"Health": {
+			"Status": "unhealthy",
+			"FailingStreak": 3,
+			"Log": [{
+				"Start": "2025-10-17T12:00:30.5Z",
+				"End": "2025-10-17T12:00:31.5Z",
+				"ExitCode": 1,
+				"Output": "connection refused"
+			}]
+		}
+	},
+	"NetworkSettings": {
+		"Bridge": "",
+		"SandboxID": "6b4c1ef0b5c9",
+		"SandboxKey": "/var/run/docker/netns/6b4c1ef0b5c9",
+		"Ports": {"22/tcp": [{"HostIp": "0.0.0.0", "HostPort": "32768"}]},
+		"Networks": {
+			"replicas": {
+				"IPAMConfig": null,
+				"Links": null,
+				"Aliases": ["replica-1"],
+				"MacAddress": "02:42:ac:13:00:02",
+				"DriverOpts": null,
+				"NetworkID": "c2a7a5b9e1f3",
+				"EndpointID": "3f5e0e4d8a21",
+				"Gateway": "172.19.0.1",
+				"IPAddress": "172.19.0.2",
+				"IPPrefixLen": 16,
+				"IPv6Gateway": "fd00::1",
+				"GlobalIPv6Address": "fd00::2",
+				"GlobalIPv6PrefixLen": 64,
+				"DNSNames": ["replica-1", "8dfafdbc3a40"]
+			}
+		}
+	}
+}`
+
+func TestContainerInspectState(t *testing.T) {
+	c := newTestClient(t, respondWith(inspectJSON))
+	insp, err := c.ContainerInspect(t.Context(), "replica-1")
+	if err != nil {
+		t.Fatalf("Failed to inspect container: %v", err)
+	}
+
+	state := insp.State
+	if state == nil {
+		t.Fatal("State is nil")
+	}
+	if state.Status != container.StateRunning || !state.Running || state.Pid != 4242 {
+		t.Errorf("State = %+v, want running with pid 4242", state)
+	}
+	if want := time.Date(2025, 10, 17, 12, 0, 0, 123456789, time.UTC); !state.StartedTime().Equal(want) {
+		t.Errorf("StartedTime() = %v, want %v", state.StartedTime(), want)
+	}
+	if got := state.FinishedTime(); !got.IsZero() {
+		t.Errorf("FinishedTime() = %v, want zero time", got)
+	}
+
+	if got := state.HealthStatus(); got != container.Unhealthy {
+		t.Errorf("HealthStatus() = %q, want %q", got, container.Unhealthy)
+	}
+	if got := (&container.State{}).HealthStatus(); got != container.NoHealthcheck {
+		t.Errorf("HealthStatus() without healthcheck = %q, want %q", got, container.NoHealthcheck)
+	}
+
+	health := state.Health
+	if health == nil {
+		t.Fatal("Health is nil")
+	}
+	if health.Status != container.Unhealthy || health.FailingStreak != 3 || len(health.Log) != 1 {
+		t.Fatalf("Health = %+v, want unhealthy with failing streak 3 and one result", health)
+	}
+	result := health.Log[0]
+	if got := result.End.Sub(result.Start); got != time.Second {
+		t.Errorf("probe duration = %v, want 1s", got)
+	}
+	if result.ExitCode != 1 || result.Output != "connection refused" {
+		t.Errorf("HealthcheckResult = %+v, want exit code 1 and output %q", result, "connection refused")
+	}
+}
+
+func TestContainerInspectNetworkSettings(t *testing.T) {
+	c := newTestClient(t, respondWith(inspectJSON))
+	insp, err := c.ContainerInspect(t.Context(), "replica-1")
+	if err != nil {
+		t.Fatalf("Failed to inspect container: %v", err)
+	}
+	if insp.NetworkSettings == nil {
+		t.Fatal("NetworkSettings is nil")
+	}
+	want := map[string]*network.EndpointSettings{
+		"replicas": {
+			Aliases:             []string{"replica-1"},
+			MacAddress:          "02:42:ac:13:00:02",
+			NetworkID:           "c2a7a5b9e1f3",
+			EndpointID:          "3f5e0e4d8a21",
+			Gateway:             "172.19.0.1",
+			IPAddress:           "172.19.0.2",
+			IPPrefixLen:         16,
+			IPv6Gateway:         "fd00::1",
+			GlobalIPv6Address:   "fd00::2",
+			GlobalIPv6PrefixLen: 64,
+			DNSNames:            []string{"replica-1", "8dfafdbc3a40"},
+		},
+	}
+	if got := insp.NetworkSettings.Networks; !reflect.DeepEqual(got, want) {
+		t.Errorf("Networks = %+v, want %+v", got["replicas"], want["replicas"])
+	}
+	if got := insp.NetworkSettings.Ports["22/tcp"]; len(got) != 1 || got[0].HostPort != "32768" {
+		t.Errorf("Ports = %+v, want 22/tcp bound to host port 32768", insp.NetworkSettings.Ports)
+	}
+}
+
+func TestContainerPortEndpoint(t *testing.T) {
+	const portsJSON = `{"NetworkSettings": {"Ports": {
+		"22/tcp": [{"HostIp": "0.0.0.0", "HostPort": "32768"}, {"HostIp": "::", "HostPort": "32768"}],
+		"53/udp": [{"HostIp": "::", "HostPort": "32769"}],
+		"80/tcp": [{"HostIp": "192.0.2.10", "HostPort": "8080"}],
+		"443/tcp": [{"HostIp": "", "HostPort": ""}],
+		"8080/tcp": [{"HostIp": "127.0.0.1", "HostPort": "8081"}],
+		"8443/tcp": [{"HostIp": "::1", "HostPort": "8444"}],
+		"9000/tcp": null
+	}}}`
+
+	sock := filepath.Join(t.TempDir(), "docker.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newFakeDaemon(t, l, respondWith(portsJSON))
+	unixClient, err := container.NewContainer(container.WithHost("unix://" + sock))
+	if err != nil {
+		t.Fatalf("Failed to create container client: %v", err)
+	}
+	tcpClient := newTestClient(t, respondWith(portsJSON)) // daemon host is 127.0.0.1
+	remoteClient := newTestClientForHost(t, "tcp://192.0.2.1:2375", respondWith(portsJSON))
+	remoteIPv6Client := newTestClientForHost(t, "tcp://[2001:db8::1]:2375", respondWith(portsJSON))
+
+	tests := []struct {
+		name    string
+		client  *container.Container
+		port    container.Port
+		want    string
+		wantErr bool
+	}{
+		{name: "UnixUnspecifiedIPv4", client: unixClient, port: "22/tcp", want: "127.0.0.1:32768"},
+		{name: "UnixUnspecifiedIPv6", client: unixClient, port: "53/udp", want: "[::1]:32769"},
+		{name: "UnixSpecificIP", client: unixClient, port: "80/tcp", want: "192.0.2.10:8080"},
+		{name: "TCPUnspecifiedIPv4", client: tcpClient, port: "22/tcp", want: "127.0.0.1:32768"},
+		{name: "TCPUnspecifiedIPv6", client: tcpClient, port: "53/udp", want: "[::1]:32769"},
+		{name: "TCPLoopbackIPv4", client: tcpClient, port: "8080/tcp", want: "127.0.0.1:8081"},
+		{name: "TCPLoopbackIPv6", client: tcpClient, port: "8443/tcp", want: "[::1]:8444"},
+		{name: "RemoteUnspecifiedIPv4", client: remoteClient, port: "22/tcp", want: "192.0.2.1:32768"},
+		{name: "RemoteUnspecifiedIPv6", client: remoteClient, port: "53/udp", wantErr: true},
+		{name: "RemoteSpecificIP", client: remoteClient, port: "80/tcp", want: "192.0.2.10:8080"},
+		{name: "RemoteLoopbackIPv4", client: remoteClient, port: "8080/tcp", wantErr: true},
+		{name: "RemoteLoopbackIPv6", client: remoteClient, port: "8443/tcp", wantErr: true},
+		{name: "RemoteIPv6UnspecifiedIPv4", client: remoteIPv6Client, port: "22/tcp", want: "[2001:db8::1]:32768"},
+		{name: "RemoteIPv6UnspecifiedIPv6", client: remoteIPv6Client, port: "53/udp", want: "[2001:db8::1]:32769"},
+		{name: "Unassigned", client: tcpClient, port: "443/tcp", wantErr: true},
+		{name: "NoBindings", client: tcpClient, port: "9000/tcp", wantErr: true},
+		{name: "Unpublished", client: tcpClient, port: "3000/tcp", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.client.PortEndpoint(t.Context(), "replica-1", tt.port)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PortEndpoint(%q) error = %v, wantErr %t", tt.port, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("PortEndpoint(%q) = %v, want %s", tt.port, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package container
+
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the originals [Summary] and [PortSummary].
+//
+// [Summary]: https://github.com/moby/moby/blob/master/api/types/container/container.go
+// [PortSummary]: https://github.com/moby/moby/blob/master/api/types/container/port.go
+
+// Summary contains response of the GET "/containers/json" endpoint.
+type Summary struct {
+	ID         string `json:"Id"`
+	Names      []string
+	Image      string
+	ImageID    string
+	Command    string
+	Created    int64
+	Ports      []PortSummary
+	SizeRw     int64 `json:",omitempty"`
+	SizeRootFs int64 `json:",omitempty"`
+	Labels     map[string]string
+	State      string
+	Status     string
+}
+
+// PortSummary describes a port mapping of a container, as reported in the
+// container [Summary].
+type PortSummary struct {
+	// Host IP address that the container's port is mapped to
+	IP string `json:"IP,omitempty"`
+
+	// Port on the container
+	// Required: true
+	PrivatePort uint16 `json:"PrivatePort"`
+
+	// Port exposed on the host
+	PublicPort uint16 `json:"PublicPort,omitempty"`
+
+	// type, e.g. "tcp", "udp" or "sctp"
+	// Required: true
+	Type string `json:"Type"`
+}
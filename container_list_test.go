@@ -0,0 +1,77 @@
+package container_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestContainerList(t *testing.T) {
+	var gotQuery map[string]string
+	var gotFilters map[string]map[string]bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.47/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery = make(map[string]string)
+		for key := range r.URL.Query() {
+			gotQuery[key] = r.URL.Query().Get(key)
+		}
+		if err := json.Unmarshal([]byte(gotQuery["filters"]), &gotFilters); err != nil {
+			t.Errorf("Failed to decode filters %q: %v", gotQuery["filters"], err)
+		}
+		_, _ = w.Write([]byte(`[{
+			"Id": "8dfafdbc3a40",
+			"Names": ["/replica-1"],
+			"Image": "container-test",
+			"Labels": {"owner": "container-test"},
+			"State": "exited",
+			"Ports": [{"IP": "0.0.0.0", "PrivatePort": 22, "PublicPort": 32768, "Type": "tcp"}]
+		}]`))
+	})
+
+	args := container.ListFilters{
+		Label:    []string{"owner=container-test"},
+		Status:   []string{"exited", "dead"},
+		Ancestor: []string{"container-test"},
+	}.Args()
+	args.Add("before", "replica-2")
+	list, err := c.ContainerList(t.Context(), container.ListOptions{
+		All:     true,
+		Limit:   5,
+		Filters: args,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list containers: %v", err)
+	}
+
+	wantQuery := map[string]string{"all": "1", "limit": "5", "filters": gotQuery["filters"]}
+	if !reflect.DeepEqual(gotQuery, wantQuery) {
+		t.Errorf("query = %v, want %v", gotQuery, wantQuery)
+	}
+	wantFilters := map[string]map[string]bool{
+		"label":    {"owner=container-test": true},
+		"status":   {"exited": true, "dead": true},
+		"ancestor": {"container-test": true},
+		"before":   {"replica-2": true},
+	}
+	if !reflect.DeepEqual(gotFilters, wantFilters) {
+		t.Errorf("filters = %v, want %v", gotFilters, wantFilters)
+	}
+
+	want := []container.Summary{{
+		ID:     "8dfafdbc3a40",
+		Names:  []string{"/replica-1"},
+		Image:  "container-test",
+		Labels: map[string]string{"owner": "container-test"},
+		State:  "exited",
+		Ports:  []container.PortSummary{{IP: "0.0.0.0", PrivatePort: 22, PublicPort: 32768, Type: "tcp"}},
+	}}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("ContainerList() = %+v, want %+v", list, want)
+	}
+}
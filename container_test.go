@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -36,7 +37,7 @@ func TestPing(t *testing.T) {
 		t.Fatalf("Failed to create container client: %v", err)
 	}
 
-	if err := c.Ping(t.Context()); err != nil {
+	if _, err := c.Ping(t.Context()); err != nil {
 		t.Fatalf("Failed to ping Docker daemon: %v", err)
 	}
 	t.Log("Ping successful")
@@ -82,8 +83,9 @@ func TestContainerCreateAndStartAndInspectAndStop(t *testing.T) {
 	t.Logf("Network created: %s", net.ID)
 
 	resp, err := c.ContainerCreate(context.Background(), &container.Config{
-		Env:   []string{"AUTHORIZED_KEYS=xyz"},
-		Image: containerTestTag,
+		Env:    []string{"AUTHORIZED_KEYS=xyz"},
+		Image:  containerTestTag,
+		Labels: map[string]string{"owner": containerTestTag},
 		ExposedPorts: container.PortSet{
 			"22/tcp": struct{}{},
 		},
@@ -102,18 +104,20 @@ func TestContainerCreateAndStartAndInspectAndStop(t *testing.T) {
 		// cannot use t.Context() here, since it may be canceled before cleanup runs
 		ctx := context.Background()
 
-		if err := c.ContainerStop(ctx, resp.ID, opts); err != nil {
-			t.Errorf("Failed to stop container '%s': %v", resp.ID, err)
-		} else {
-			t.Logf("Container stopped: %s", resp.ID)
-		}
-
+		// Disconnect before stopping, since the container is removed when
+		// stopped (AutoRemove).
 		if err := c.NetworkDisconnect(ctx, net.ID, resp.ID, true); err != nil {
 			t.Errorf("Failed to disconnect container from network '%s': %v", net.ID, err)
 		} else {
 			t.Logf("Container disconnected from network: %s", net.ID)
 		}
 
+		if err := c.ContainerStop(ctx, resp.ID, opts); err != nil {
+			t.Errorf("Failed to stop container '%s': %v", resp.ID, err)
+		} else {
+			t.Logf("Container stopped: %s", resp.ID)
+		}
+
 		if err := c.NetworkRemove(ctx, net.ID); err != nil {
 			t.Errorf("Failed to remove network: %v", err)
 		} else {
@@ -133,6 +137,24 @@ func TestContainerCreateAndStartAndInspectAndStop(t *testing.T) {
 		t.Fatalf("Failed to inspect container: %v", err)
 	}
 	t.Logf("Container inspected: %+v", insp)
+	if insp.State == nil || !insp.State.Running || insp.State.Pid == 0 {
+		t.Errorf("Container state = %+v, want running", insp.State)
+	} else if insp.State.StartedTime().IsZero() {
+		t.Errorf("Container StartedAt = %q, want start time", insp.State.StartedAt)
+	}
+
+	list, err := c.ContainerList(t.Context(), container.ListOptions{
+		Filters: container.ListFilters{
+			Ancestor: []string{containerTestTag},
+			Label:    []string{"owner=" + containerTestTag},
+		}.Args(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to list containers: %v", err)
+	}
+	if !slices.ContainsFunc(list, func(s container.Summary) bool { return s.ID == resp.ID }) {
+		t.Errorf("Container %s not found in container list: %+v", resp.ID, list)
+	}
 
 	name := strings.TrimPrefix(insp.Name, "/")
 	err = c.NetworkConnect(context.Background(), net.ID, resp.ID, &network.EndpointSettings{
@@ -142,8 +164,42 @@ func TestContainerCreateAndStartAndInspectAndStop(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	insp, err = c.ContainerInspect(t.Context(), resp.ID)
+	if err != nil {
+		t.Fatalf("Failed to inspect container: %v", err)
+	}
+	var endpoint *network.EndpointSettings
+	for _, ep := range insp.NetworkSettings.Networks {
+		if ep.NetworkID == net.ID {
+			endpoint = ep
+		}
+	}
+	if endpoint == nil || endpoint.IPAddress == "" {
+		t.Errorf("Container networks = %+v, want endpoint with IP address in network %s", insp.NetworkSettings.Networks, net.ID)
+	} else {
+		t.Logf("Container IP address: %s", endpoint.IPAddress)
+	}
+
+	stdout, stderr, exitCode, err := c.Exec(t.Context(), resp.ID, []string{"sh", "-c", "pgrep sshd; echo probe >&2; exit 3"})
+	if err != nil {
+		t.Fatalf("Failed to exec command: %v", err)
+	}
+	if len(stdout) == 0 {
+		t.Errorf("Exec stdout is empty; expected sshd pid")
+	}
+	if got := string(stderr); got != "probe\n" {
+		t.Errorf("Exec stderr = %q, want %q", got, "probe\n")
+	}
+	if exitCode != 3 {
+		t.Errorf("Exec exit code = %d, want 3", exitCode)
+	}
+
 	t.Logf("Container name: %s", name)
-	t.Logf("Container network settings: %+v", insp.NetworkSettings.Ports)
+	sshEndpoint, err := c.PortEndpoint(t.Context(), resp.ID, "22/tcp")
+	if err != nil {
+		t.Fatalf("Failed to resolve ssh endpoint: %v", err)
+	}
+	t.Logf("Container ssh endpoint: %v", sshEndpoint)
 }
 
 func TestBuild(t *testing.T) {
@@ -0,0 +1,344 @@
+package container_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/relab/container"
+	"github.com/relab/container/mount"
+)
+
+// recordCreateBody returns a handler for container create requests that
+// records the JSON request body in body.
+func recordCreateBody(t *testing.T, body *map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read request body: %v", err)
+		}
+		if err := json.Unmarshal(data, body); err != nil {
+			t.Errorf("Failed to decode request body %s: %v", data, err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"Id":"8dfafdbc3a40","Warnings":[]}`))
+	}
+}
+
+func TestContainerCreateHealthcheck(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	_, err := c.ContainerCreate(t.Context(), &container.Config{
+		Image: "container-test",
+		Healthcheck: &container.HealthConfig{
+			Test:          []string{"CMD-SHELL", "pgrep sshd"},
+			Interval:      2 * time.Second,
+			Timeout:       500 * time.Millisecond,
+			StartPeriod:   time.Minute,
+			StartInterval: 100 * time.Millisecond,
+			Retries:       3,
+		},
+	}, nil, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	want := map[string]any{
+		"Test":          []any{"CMD-SHELL", "pgrep sshd"},
+		"Interval":      float64(2e9),
+		"Timeout":       float64(5e8),
+		"StartPeriod":   float64(6e10),
+		"StartInterval": float64(1e8),
+		"Retries":       float64(3),
+	}
+	if got := body["Healthcheck"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Healthcheck = %v, want %v", got, want)
+	}
+}
+
+func TestContainerCreateWithoutHealthcheck(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	if _, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, nil, nil, ""); err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	if got, ok := body["Healthcheck"]; ok {
+		t.Errorf("Healthcheck = %v, want omitted", got)
+	}
+}
+
+func TestContainerCreateConfig(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	stopTimeout := 5
+	_, err := c.ContainerCreate(t.Context(), &container.Config{
+		Hostname:    "replica-1",
+		Domainname:  "test.local",
+		Image:       "container-test",
+		Entrypoint:  []string{"/bin/sh", "-c"},
+		Cmd:         []string{"sleep infinity"},
+		WorkingDir:  "/srv",
+		Labels:      map[string]string{"owner": "container-test"},
+		Tty:         true,
+		OpenStdin:   true,
+		StopSignal:  "SIGINT",
+		StopTimeout: &stopTimeout,
+	}, nil, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	want := map[string]any{
+		"Hostname":    "replica-1",
+		"Domainname":  "test.local",
+		"Image":       "container-test",
+		"Entrypoint":  []any{"/bin/sh", "-c"},
+		"Cmd":         []any{"sleep infinity"},
+		"WorkingDir":  "/srv",
+		"Labels":      map[string]any{"owner": "container-test"},
+		"Tty":         true,
+		"OpenStdin":   true,
+		"StopSignal":  "SIGINT",
+		"StopTimeout": float64(5),
+	}
+	for key, value := range want {
+		if got := body[key]; !reflect.DeepEqual(got, value) {
+			t.Errorf("%s = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestContainerCreateConfigOmitEmpty(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	if _, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, nil, nil, ""); err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	for _, key := range []string{"ExposedPorts", "Healthcheck", "StopSignal", "StopTimeout"} {
+		if got, ok := body[key]; ok {
+			t.Errorf("%s = %v, want omitted", key, got)
+		}
+	}
+	// A nil Entrypoint is sent as null, which keeps the image's entrypoint.
+	if got, ok := body["Entrypoint"]; !ok || got != nil {
+		t.Errorf("Entrypoint = %v, want null", got)
+	}
+}
+
+func TestContainerCreateResources(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	pidsLimit := int64(100)
+	_, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &container.HostConfig{
+		Resources: container.Resources{
+			Memory:      256 << 20,
+			MemorySwap:  -1,
+			NanoCPUs:    500_000_000,
+			CPUShares:   512,
+			CpusetCpus:  "0,1",
+			PidsLimit:   &pidsLimit,
+			BlkioWeight: 300,
+			Ulimits:     []*container.Ulimit{{Name: "nofile", Soft: 1024, Hard: 2048}},
+		},
+	}, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	hostConfig, _ := body["HostConfig"].(map[string]any)
+	want := map[string]any{
+		"Memory":      float64(256 << 20),
+		"MemorySwap":  float64(-1),
+		"NanoCpus":    float64(500_000_000),
+		"CpuShares":   float64(512),
+		"CpusetCpus":  "0,1",
+		"PidsLimit":   float64(100),
+		"BlkioWeight": float64(300),
+		"Ulimits":     []any{map[string]any{"Name": "nofile", "Soft": float64(1024), "Hard": float64(2048)}},
+	}
+	for key, value := range want {
+		if got := hostConfig[key]; !reflect.DeepEqual(got, value) {
+			t.Errorf("HostConfig.%s = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestContainerCreateRestartPolicy(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	initProcess := true
+	oomKillDisable := true
+	_, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3},
+		Init:          &initProcess,
+		ShmSize:       64 << 20,
+		Resources:     container.Resources{OomKillDisable: &oomKillDisable},
+	}, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	hostConfig, _ := body["HostConfig"].(map[string]any)
+	want := map[string]any{
+		"RestartPolicy":  map[string]any{"Name": "on-failure", "MaximumRetryCount": float64(3)},
+		"Init":           true,
+		"ShmSize":        float64(64 << 20),
+		"OomKillDisable": true,
+	}
+	for key, value := range want {
+		if got := hostConfig[key]; !reflect.DeepEqual(got, value) {
+			t.Errorf("HostConfig.%s = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestContainerCreateInvalidHostConfig(t *testing.T) {
+	tests := map[string]container.HostConfig{
+		"AutoRemoveWithRestartPolicy": {
+			AutoRemove:    true,
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		"MaximumRetryCountWithAlways": {
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyAlways, MaximumRetryCount: 2},
+		},
+		"NegativeMaximumRetryCount": {
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: -1},
+		},
+		"UnknownRestartPolicy": {
+			RestartPolicy: container.RestartPolicy{Name: "sometimes"},
+		},
+		"EmptyContainerNetworkMode": {
+			NetworkMode: container.NetworkModeContainer(""),
+		},
+		"ContainerNetworkModeWithPortBindings": {
+			NetworkMode:  container.NetworkModeContainer("replica-1"),
+			PortBindings: container.PortMap{"22/tcp": {{}}},
+		},
+		"ContainerNetworkModeWithPublishAllPorts": {
+			NetworkMode:     container.NetworkModeContainer("replica-1"),
+			PublishAllPorts: true,
+		},
+		"ContainerNetworkModeWithDNS": {
+			NetworkMode: container.NetworkModeContainer("replica-1"),
+			DNS:         []string{"1.1.1.1"},
+		},
+		"ContainerNetworkModeWithExtraHosts": {
+			NetworkMode: container.NetworkModeContainer("replica-1"),
+			ExtraHosts:  []string{"db:10.0.0.5"},
+		},
+		"TmpfsMountWithSource": {
+			Mounts: []mount.Mount{{Type: mount.TypeTmpfs, Source: "/tmp", Target: "/tmp"}},
+		},
+	}
+	for name, hostConfig := range tests {
+		t.Run(name, func(t *testing.T) {
+			var body map[string]any
+			c := newTestClient(t, recordCreateBody(t, &body))
+			if _, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &hostConfig, nil, ""); err == nil {
+				t.Error("ContainerCreate(): expected error")
+			}
+			if body != nil {
+				t.Errorf("ContainerCreate() sent request for invalid host config: %v", body)
+			}
+		})
+	}
+
+	// AutoRemove is valid with the default and the explicit "no" restart policy.
+	for _, name := range []container.RestartPolicyMode{"", container.RestartPolicyDisabled} {
+		var body map[string]any
+		c := newTestClient(t, recordCreateBody(t, &body))
+		hostConfig := &container.HostConfig{AutoRemove: true, RestartPolicy: container.RestartPolicy{Name: name}}
+		if _, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, hostConfig, nil, ""); err != nil {
+			t.Errorf("ContainerCreate() with AutoRemove and restart policy %q: %v", name, err)
+		}
+	}
+}
+
+func TestContainerCreateSecurityOptions(t *testing.T) {
+	seccomp, err := container.SeccompProfile([]byte(`{
+		"defaultAction": "SCMP_ACT_ALLOW",
+		"syscalls": []
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `seccomp={"defaultAction":"SCMP_ACT_ALLOW","syscalls":[]}`; seccomp != want {
+		t.Errorf("SeccompProfile() = %s, want %s", seccomp, want)
+	}
+	if _, err := container.SeccompProfile([]byte("not json")); err == nil {
+		t.Error("SeccompProfile() with invalid JSON: expected error")
+	}
+
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	_, err = c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &container.HostConfig{
+		CapAdd:         []string{"NET_ADMIN"},
+		CapDrop:        []string{"MKNOD"},
+		GroupAdd:       []string{"wheel"},
+		ReadonlyRootfs: true,
+		SecurityOpt:    []string{seccomp, container.AppArmorProfile("docker-default"), container.NoNewPrivileges},
+		UsernsMode:     "host",
+		Resources: container.Resources{
+			Devices: []container.DeviceMapping{{PathOnHost: "/dev/net/tun", PathInContainer: "/dev/net/tun", CgroupPermissions: "rwm"}},
+		},
+	}, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	hostConfig, _ := body["HostConfig"].(map[string]any)
+	want := map[string]any{
+		"CapAdd":         []any{"NET_ADMIN"},
+		"CapDrop":        []any{"MKNOD"},
+		"GroupAdd":       []any{"wheel"},
+		"Privileged":     false,
+		"ReadonlyRootfs": true,
+		"SecurityOpt":    []any{seccomp, "apparmor=docker-default", "no-new-privileges"},
+		"UsernsMode":     "host",
+		"Devices":        []any{map[string]any{"PathOnHost": "/dev/net/tun", "PathInContainer": "/dev/net/tun", "CgroupPermissions": "rwm"}},
+	}
+	for key, value := range want {
+		if got := hostConfig[key]; !reflect.DeepEqual(got, value) {
+			t.Errorf("HostConfig.%s = %v, want %v", key, got, value)
+		}
+	}
+}
+
+func TestContainerCreateNetworking(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, recordCreateBody(t, &body))
+	_, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &container.HostConfig{
+		NetworkMode:     "replicas",
+		DNS:             []string{"10.0.0.2"},
+		DNSOptions:      []string{"ndots:1"},
+		DNSSearch:       []string{"test.local"},
+		ExtraHosts:      []string{"host.docker.internal:host-gateway"},
+		PublishAllPorts: true,
+	}, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	hostConfig, _ := body["HostConfig"].(map[string]any)
+	want := map[string]any{
+		"NetworkMode":     "replicas",
+		"Dns":             []any{"10.0.0.2"},
+		"DnsOptions":      []any{"ndots:1"},
+		"DnsSearch":       []any{"test.local"},
+		"ExtraHosts":      []any{"host.docker.internal:host-gateway"},
+		"PublishAllPorts": true,
+	}
+	for key, value := range want {
+		if got := hostConfig[key]; !reflect.DeepEqual(got, value) {
+			t.Errorf("HostConfig.%s = %v, want %v", key, got, value)
+		}
+	}
+
+	// A sidecar sharing the network namespace of another container.
+	if _, err := c.ContainerCreate(t.Context(), &container.Config{Image: "container-test"}, &container.HostConfig{
+		NetworkMode: container.NetworkModeContainer("replica-1"),
+	}, nil, ""); err != nil {
+		t.Fatalf("Failed to create sidecar container: %v", err)
+	}
+	hostConfig, _ = body["HostConfig"].(map[string]any)
+	if got, want := hostConfig["NetworkMode"], "container:replica-1"; got != want {
+		t.Errorf("HostConfig.NetworkMode = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const errorMsgLimit = 4 * 1024 // 4KiB
+
+// APIError is returned when the docker daemon responds to a request with an
+// error status. It holds the error message reported by the daemon.
+//
+// Use [IsNotFound], [IsConflict], [IsUnauthorized] and [IsForbidden] to check
+// for common error conditions, or [errors.As] to access the error details.
+type APIError struct {
+	StatusCode int    // StatusCode is the HTTP status code of the response
+	Method     string // Method is the HTTP method of the request
+	Endpoint   string // Endpoint is the request path, e.g. "/v1.51/containers/create"
+	Message    string // Message is the error message returned by the daemon, if any
+
+	op string // operation that failed, e.g. "container creation failed"
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return fmt.Sprintf("%s: %s", e.op, status)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.op, status, e.Message)
+}
+
+// IsNotFound reports whether err is an [APIError] with status 404 Not Found,
+// e.g. because the container, image or network does not exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an [APIError] with status 409 Conflict,
+// e.g. because a container with the same name already exists.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether err is an [APIError] with status 401 Unauthorized.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an [APIError] with status 403 Forbidden.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// checkResponse returns nil if resp has a 2xx status code. Otherwise, it
+// returns an [APIError] holding the error message from the response body,
+// with op describing the operation that failed.
+func checkResponse(resp *http.Response, op string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		op:         op,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+	if resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, errorMsgLimit))
+		apiErr.Message = errorMessage(resp.Header.Get("Content-Type"), body)
+	}
+	return apiErr
+}
+
+// errorMessage extracts the error message from an error response body.
+// The daemon reports errors as JSON objects of the form {"message": "..."},
+// but responses from proxies may be plain text.
+func errorMessage(contentType string, body []byte) string {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/json" {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(body, &errResp); err == nil {
+			return strings.TrimSpace(errResp.Message)
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,57 @@
+package container_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestAPIError(t *testing.T) {
+	const conflictMsg = `Conflict. The container name "/replica-1" is already in use`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/containers/create"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"` + strings.ReplaceAll(conflictMsg, `"`, `\"`) + `"}`))
+		default:
+			http.Error(w, "page not found", http.StatusNotFound)
+		}
+	})
+
+	_, err := c.ContainerCreate(t.Context(), &container.Config{Image: "alpine"}, nil, nil, "replica-1")
+	if !container.IsConflict(err) {
+		t.Fatalf("ContainerCreate() error = %v, want conflict", err)
+	}
+	var apiErr *container.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("ContainerCreate() error = %T, want *container.APIError", err)
+	}
+	want := container.APIError{
+		StatusCode: http.StatusConflict,
+		Method:     http.MethodPost,
+		Endpoint:   "/v1.47/containers/create",
+		Message:    conflictMsg,
+	}
+	if apiErr.StatusCode != want.StatusCode || apiErr.Method != want.Method ||
+		apiErr.Endpoint != want.Endpoint || apiErr.Message != want.Message {
+		t.Errorf("ContainerCreate() error = %+v, want %+v", *apiErr, want)
+	}
+	if got, want := err.Error(), "container creation failed: 409 Conflict: "+conflictMsg; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	_, err = c.ContainerInspect(t.Context(), "missing")
+	if !container.IsNotFound(err) {
+		t.Fatalf("ContainerInspect() error = %v, want not found", err)
+	}
+	if container.IsConflict(err) || container.IsUnauthorized(err) || container.IsForbidden(err) {
+		t.Errorf("ContainerInspect() error = %v matches unexpected predicate", err)
+	}
+	if got, want := err.Error(), "container inspect failed: 404 Not Found: page not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package container
+
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the originals [ExecOptions], [ExecStartOptions] and [ExecInspect].
+//
+// [ExecOptions]: https://github.com/moby/moby/blob/master/api/types/container/exec.go
+// [ExecStartOptions]: https://github.com/moby/moby/blob/master/api/types/container/exec.go
+// [ExecInspect]: https://github.com/moby/moby/blob/master/api/types/container/exec.go
+
+// ExecOptions is a small subset of the Config struct that holds the configuration
+// for the exec feature of docker.
+type ExecOptions struct {
+	User         string   // User that will run the command
+	Privileged   bool     // Is the container in privileged mode
+	Tty          bool     // Attach standard streams to a tty.
+	ConsoleSize  *[2]uint `json:",omitempty"` // Initial console size [height, width]
+	AttachStdin  bool     // Attach the standard input, makes possible user interaction
+	AttachStderr bool     // Attach the standard error
+	AttachStdout bool     // Attach the standard output
+	DetachKeys   string   // Escape keys for detach
+	Env          []string // Environment variables
+	WorkingDir   string   // Working directory
+	Cmd          []string // Execution commands and args
+}
+
+// ExecStartOptions holds parameters to start an exec instance with.
+type ExecStartOptions struct {
+	Detach      bool     // Detach starts the command without attaching to its standard streams
+	Tty         bool     // Tty allocates a pseudo-TTY; the output is then not multiplexed
+	ConsoleSize *[2]uint `json:",omitempty"` // ConsoleSize is the initial TTY size [height, width]; ignored without Tty
+}
+
+// ExecCreateResponse is the response returned from the server when creating
+// an exec instance.
+type ExecCreateResponse struct {
+	// The ID of the created exec instance
+	// Required: true
+	ID string `json:"Id"`
+}
+
+// ExecInspect holds information returned by exec inspect.
+type ExecInspect struct {
+	ExecID      string `json:"ID"`
+	ContainerID string
+	Running     bool
+	ExitCode    int
+	Pid         int
+}
@@ -0,0 +1,58 @@
+package container_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestExec(t *testing.T) {
+	var gotOptions container.ExecOptions
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.47/containers/replica-1/exec":
+			if err := json.NewDecoder(r.Body).Decode(&gotOptions); err != nil {
+				t.Errorf("Failed to decode exec options: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"Id":"exec-1"}`))
+		case "/v1.47/exec/exec-1/start":
+			_, _ = w.Write(frame(1, "sshd\n"))
+			_, _ = w.Write(frame(2, "probe\n"))
+			_, _ = w.Write(frame(1, "done\n"))
+		case "/v1.47/exec/exec-1/json":
+			_, _ = w.Write([]byte(`{"ID":"exec-1","ContainerID":"replica-1","Running":false,"ExitCode":3}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	cmd := []string{"sh", "-c", "pgrep sshd; echo probe >&2; exit 3"}
+	stdout, stderr, exitCode, err := c.Exec(t.Context(), "replica-1", cmd)
+	if err != nil {
+		t.Fatalf("Failed to exec command: %v", err)
+	}
+	if !slices.Equal(gotOptions.Cmd, cmd) || !gotOptions.AttachStdout || !gotOptions.AttachStderr {
+		t.Errorf("exec options = %+v, want Cmd %q with stdout and stderr attached", gotOptions, cmd)
+	}
+	if got, want := string(stdout), "sshd\ndone\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := string(stderr), "probe\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+	if exitCode != 3 {
+		t.Errorf("exit code = %d, want 3", exitCode)
+	}
+}
+
+// frame returns payload as a frame of a multiplexed stream of the given type.
+func frame(streamType byte, payload string) []byte {
+	header := []byte{streamType, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
@@ -0,0 +1,115 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+)
+
+// Args stores a set of filter values indexed by filter name, e.g. "label"
+// or "status". The daemon returns objects that match at least one of the
+// values of each filter.
+//
+// The zero value is an empty set of filters ready to use.
+//
+// This is a simplified version of the Docker API's [Args].
+//
+// [Args]: https://pkg.go.dev/github.com/docker/docker/api/types/filters#Args
+type Args struct {
+	fields map[string]map[string]bool
+}
+
+// KeyValuePair is a filter name and value to be added to Args.
+type KeyValuePair struct {
+	Key   string
+	Value string
+}
+
+// Arg creates a new KeyValuePair for use with NewArgs.
+func Arg(key, value string) KeyValuePair {
+	return KeyValuePair{Key: key, Value: value}
+}
+
+// NewArgs returns a new Args populated with the given key-value pairs.
+func NewArgs(pairs ...KeyValuePair) Args {
+	var args Args
+	for _, pair := range pairs {
+		args.Add(pair.Key, pair.Value)
+	}
+	return args
+}
+
+// Add adds value to the set of values for the filter key.
+func (a *Args) Add(key, value string) {
+	if a.fields == nil {
+		a.fields = make(map[string]map[string]bool)
+	}
+	if a.fields[key] == nil {
+		a.fields[key] = make(map[string]bool)
+	}
+	a.fields[key][value] = true
+}
+
+// Del removes value from the set of values for the filter key.
+func (a *Args) Del(key, value string) {
+	if values, ok := a.fields[key]; ok {
+		delete(values, value)
+		if len(values) == 0 {
+			delete(a.fields, key)
+		}
+	}
+}
+
+// Contains reports whether the filter key has any values.
+func (a Args) Contains(key string) bool {
+	_, ok := a.fields[key]
+	return ok
+}
+
+// Get returns the sorted list of values for the filter key.
+func (a Args) Get(key string) []string {
+	values := make([]string, 0, len(a.fields[key]))
+	for value := range a.fields[key] {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+	return values
+}
+
+// Keys returns the sorted list of filter names.
+func (a Args) Keys() []string {
+	keys := make([]string, 0, len(a.fields))
+	for key := range a.fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Len returns the number of filter names.
+func (a Args) Len() int {
+	return len(a.fields)
+}
+
+// Encode returns the JSON encoding of the filters, in the format expected
+// by the filters query parameter, or the empty string if Args is empty.
+func (a Args) Encode() (string, error) {
+	if a.Len() == 0 {
+		return "", nil
+	}
+	buf, err := json.Marshal(a.fields)
+	return string(buf), err
+}
+
+// SetQuery sets the filters query parameter to the encoded filters.
+// The query is left unchanged if Args is empty.
+func (a Args) SetQuery(query url.Values) error {
+	filters, err := a.Encode()
+	if err != nil {
+		return err
+	}
+	if filters != "" {
+		query.Set("filters", filters)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package filters_test
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/relab/container/filters"
+)
+
+func TestArgs(t *testing.T) {
+	var args filters.Args
+	if got := args.Len(); got != 0 {
+		t.Errorf("zero Args: Len() = %d, want 0", got)
+	}
+	args.Add("label", "owner=test")
+	args.Add("label", "env")
+	args.Add("status", "exited")
+	args.Add("status", "exited")
+
+	if !args.Contains("label") || !args.Contains("status") {
+		t.Errorf("Contains() = false, want true for label and status")
+	}
+	if args.Contains("name") {
+		t.Errorf("Contains(%q) = true, want false", "name")
+	}
+	if got, want := args.Get("label"), []string{"env", "owner=test"}; !slices.Equal(got, want) {
+		t.Errorf("Get(%q) = %v, want %v", "label", got, want)
+	}
+	if got, want := args.Keys(), []string{"label", "status"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+
+	args.Del("status", "exited")
+	args.Del("name", "missing")
+	if args.Contains("status") {
+		t.Errorf("Contains(%q) = true after deleting its only value", "status")
+	}
+	if got, want := args.Len(), 1; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestArgsEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		args filters.Args
+		want string
+	}{
+		{name: "Empty", args: filters.Args{}, want: ""},
+		{name: "Single", args: filters.NewArgs(filters.Arg("name", "replica")), want: `{"name":{"replica":true}}`},
+		{
+			name: "Multiple",
+			args: filters.NewArgs(filters.Arg("status", "running"), filters.Arg("label", "a=b"), filters.Arg("status", "paused")),
+			want: `{"label":{"a=b":true},"status":{"paused":true,"running":true}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.Encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = %s, want %s", got, tt.want)
+			}
+
+			query := url.Values{}
+			if err := tt.args.SetQuery(query); err != nil {
+				t.Fatal(err)
+			}
+			if got := query.Get("filters"); got != tt.want {
+				t.Errorf("SetQuery(): filters = %s, want %s", got, tt.want)
+			}
+			if tt.want == "" && query.Has("filters") {
+				t.Errorf("SetQuery(): filters set for empty Args")
+			}
+		})
+	}
+}
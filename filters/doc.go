@@ -0,0 +1,3 @@
+// Package filters provides the Args type used to encode the filters query
+// parameter accepted by the Docker API's list, prune and events endpoints.
+package filters
@@ -0,0 +1,93 @@
+package container
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HijackedResponse holds a connection to the daemon that has been upgraded
+// from HTTP to a raw bidirectional stream, as returned by ContainerAttach and
+// ExecAttach. The output of the container or command should be read from
+// Reader, which may hold data buffered while reading the HTTP response, and
+// input should be written to Conn.
+type HijackedResponse struct {
+	Conn      net.Conn
+	Reader    *bufio.Reader
+	mediaType string
+}
+
+// Close closes the hijacked connection.
+func (h *HijackedResponse) Close() error {
+	return h.Conn.Close()
+}
+
+// CloseWrite closes the write side of the hijacked connection, signaling the
+// end of the input to the container or command, while output can still be read.
+// It does nothing if the connection does not support half-closing.
+func (h *HijackedResponse) CloseWrite() error {
+	if conn, ok := h.Conn.(interface{ CloseWrite() error }); ok {
+		return conn.CloseWrite()
+	}
+	return nil
+}
+
+// MediaType returns the media type of the output stream, if reported by the
+// daemon: "application/vnd.docker.multiplexed-stream" if stdout and stderr
+// are multiplexed, or "application/vnd.docker.raw-stream" otherwise.
+func (h *HijackedResponse) MediaType() (string, bool) {
+	return h.mediaType, h.mediaType != ""
+}
+
+// hijack sends req to the daemon over a new connection, asking for the
+// connection to be upgraded to a raw stream, and returns the connection.
+// Unlike requests sent with the HTTP client, the connection is dialed
+// directly, using the host configured for the Container.
+// The op describes the operation in case the daemon returns an error.
+func (c *Container) hijack(req *http.Request, op string) (HijackedResponse, error) {
+	if err := c.prepareRequest(req); err != nil {
+		return HijackedResponse{}, err
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	ctx := req.Context()
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return HijackedResponse{}, err
+	}
+	// Abort the handshake by closing the connection if ctx is canceled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+
+	br := bufio.NewReader(conn)
+	resp, err := roundTrip(conn, br, req)
+	if !stop() {
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = conn.Close()
+		return HijackedResponse{}, err
+	}
+	// Older daemons respond with 200 OK instead of 101 Switching Protocols.
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		if err := checkResponse(resp, op); err != nil {
+			_ = conn.Close()
+			return HijackedResponse{}, err
+		}
+	}
+	return HijackedResponse{
+		Conn:      conn,
+		Reader:    br,
+		mediaType: strings.TrimSpace(resp.Header.Get("Content-Type")),
+	}, nil
+}
+
+// roundTrip writes req to conn and reads the response headers from br.
+func roundTrip(conn net.Conn, br *bufio.Reader, req *http.Request) (*http.Response, error) {
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	return http.ReadResponse(br, req)
+}
@@ -0,0 +1,72 @@
+package container_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestExecAttach(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.47/exec/exec-1/start" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Upgrade") != "tcp" {
+			http.Error(w, "missing upgrade header", http.StatusBadRequest)
+			return
+		}
+		// Consume the request body so that only the attached input remains.
+		_, _ = io.Copy(io.Discard, r.Body)
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("Failed to hijack connection: %v", err)
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = buf.WriteString("HTTP/1.1 101 UPGRADED\r\n" +
+			"Content-Type: application/vnd.docker.raw-stream\r\n" +
+			"Connection: Upgrade\r\n" +
+			"Upgrade: tcp\r\n\r\n")
+		_ = buf.Flush()
+		// Echo the input back until the client closes its write side.
+		_, _ = io.Copy(conn, buf)
+	})
+
+	resp, err := c.ExecAttach(t.Context(), "exec-1", container.ExecStartOptions{Tty: true})
+	if err != nil {
+		t.Fatalf("Failed to attach to exec instance: %v", err)
+	}
+	defer func() { _ = resp.Close() }()
+
+	if mediaType, ok := resp.MediaType(); !ok || mediaType != "application/vnd.docker.raw-stream" {
+		t.Errorf("MediaType() = %q, %t, want raw stream", mediaType, ok)
+	}
+	if _, err := resp.Conn.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("Failed to write to hijacked connection: %v", err)
+	}
+	if err := resp.CloseWrite(); err != nil {
+		t.Fatalf("Failed to close write side: %v", err)
+	}
+	out, err := io.ReadAll(resp.Reader)
+	if err != nil {
+		t.Fatalf("Failed to read from hijacked connection: %v", err)
+	}
+	if got, want := string(out), "hello\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestContainerAttachNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"No such container: missing"}`))
+	})
+	_, err := c.ContainerAttach(t.Context(), "missing", container.AttachOptions{Stream: true, Stdout: true})
+	if !container.IsNotFound(err) {
+		t.Fatalf("ContainerAttach() error = %v, want not found", err)
+	}
+}
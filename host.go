@@ -0,0 +1,110 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// EnvOverrideHost is the name of the environment variable that can be used
+// to override the default host to connect to (DefaultDockerHost).
+const EnvOverrideHost = "DOCKER_HOST"
+
+// hostFromEnv returns the docker host given by the DOCKER_HOST environment
+// variable, or DefaultDockerHost if it is unset or empty.
+func hostFromEnv() string {
+	if host := os.Getenv(EnvOverrideHost); host != "" {
+		return host
+	}
+	return DefaultDockerHost
+}
+
+// Default ports of a tcp:// docker host without a port.
+const (
+	defaultHTTPPort = "2375"
+	defaultTLSPort  = "2376"
+)
+
+// parseHost parses a docker host URL, such as "unix:///var/run/docker.sock"
+// or "tcp://127.0.0.1:2375", and returns the network and address to dial.
+// Supported schemes are unix://, tcp:// and http://; the address of a tcp
+// host may lack a port, see withDefaultPort. Windows named pipes (npipe://)
+// are not supported.
+func parseHost(host string) (network, addr string, err error) {
+	proto, addr, ok := strings.Cut(strings.TrimSpace(host), "://")
+	if !ok {
+		return "", "", fmt.Errorf("unable to parse docker host %q", host)
+	}
+	switch proto {
+	case "unix":
+		if addr == "" {
+			return "", "", fmt.Errorf("docker host %q: missing socket path", host)
+		}
+		return proto, addr, nil
+
+	case "tcp", "http":
+		u, err := url.Parse("tcp://" + addr)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to parse docker host %q: %w", host, err)
+		}
+		if u.Path != "" && u.Path != "/" {
+			return "", "", fmt.Errorf("docker host %q: paths are not supported", host)
+		}
+		if u.Hostname() == "" || strings.HasSuffix(u.Host, ":") {
+			return "", "", fmt.Errorf("docker host %q: missing host or port", host)
+		}
+		return "tcp", u.Host, nil
+
+	case "npipe":
+		return "", "", fmt.Errorf("docker host %q: windows named pipes are not supported", host)
+	}
+	return "", "", fmt.Errorf("docker host %q: unsupported protocol %q", host, proto)
+}
+
+// withDefaultPort returns the tcp address addr, adding the default docker
+// port if it has none: 2376 if TLS is used, and 2375 otherwise.
+func withDefaultPort(addr string, useTLS bool) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	port := defaultHTTPPort
+	if useTLS {
+		port = defaultTLSPort
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), port)
+}
+
+// daemonHostAddr returns the IP address of the host in the tcp address addr,
+// resolving host names. The network must be "ip4" or "ip6" to require an
+// address of that IP version, or "ip" to prefer IPv4 addresses.
+func daemonHostAddr(ctx context.Context, addr, network string) (netip.Addr, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	ips := make([]netip.Addr, 1)
+	if ips[0], err = netip.ParseAddr(host); err != nil {
+		ips, err = net.DefaultResolver.LookupNetIP(ctx, network, host)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("unable to resolve docker host %q: %w", host, err)
+		}
+	}
+	want6 := network == "ip6"
+	for _, ip := range ips {
+		if ip = ip.Unmap(); ip.Is6() == want6 {
+			return ip, nil
+		}
+	}
+	if network == "ip" && len(ips) > 0 {
+		return ips[0], nil
+	}
+	version := "IPv4"
+	if want6 {
+		version = "IPv6"
+	}
+	return netip.Addr{}, fmt.Errorf("docker host %q has no %s address", host, version)
+}
@@ -1,6 +1,11 @@
 package container
 
-import "github.com/relab/container/mount"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/relab/container/mount"
+)
 
 // The struct definitions in this file is largely copied from the Docker API
 // types, but may have been simplified for our use case.
@@ -16,9 +21,160 @@ import "github.com/relab/container/mount"
 // [HostConfig]: https://pkg.go.dev/github.com/docker/docker/api/types/container#HostConfig
 type HostConfig struct {
 	// Applicable to all platforms
-	PortBindings PortMap // Port mapping between the exposed port (container) and the host
-	AutoRemove   bool    // Automatically remove container when it exits
+	NetworkMode   NetworkMode   // Network mode to use for the container
+	PortBindings  PortMap       // Port mapping between the exposed port (container) and the host
+	RestartPolicy RestartPolicy // Restart policy to be used for the container
+	AutoRemove    bool          // Automatically remove container when it exits
+
+	// Applicable to UNIX platforms
+	CapAdd          []string   // List of kernel capabilities to add to the container, e.g. "NET_ADMIN"
+	CapDrop         []string   // List of kernel capabilities to remove from the container
+	DNS             []string   `json:"Dns"`        // List of DNS server to lookup
+	DNSOptions      []string   `json:"DnsOptions"` // List of DNSOption to look for
+	DNSSearch       []string   `json:"DnsSearch"`  // List of DNSSearch to look for
+	ExtraHosts      []string   // List of extra hosts, e.g. "db:10.0.0.5" or "host.docker.internal:host-gateway"
+	GroupAdd        []string   // List of additional groups that the container process will run as
+	Privileged      bool       // Is the container in privileged mode
+	PublishAllPorts bool       // Should docker publish all exposed port for the container
+	ReadonlyRootfs  bool       // Is the container root filesystem in read-only
+	SecurityOpt     []string   // List of security options, e.g. NoNewPrivileges or AppArmorProfile("name")
+	UsernsMode      UsernsMode // The user namespace to use for the container
+	ShmSize         int64      // Total shm memory usage
+
+	// Run a custom init inside the container, if null, use the daemon's configured settings
+	Init *bool `json:",omitempty"`
+
+	// Contains container's resources (cgroups, ulimits)
+	Resources
 
 	// Mounts specs used by the container
 	Mounts []mount.Mount `json:",omitempty"`
 }
+
+// Resources contains container's resources (cgroups config, ulimits...)
+//
+// This is a simplified version of the Docker API's [Resources].
+//
+// [Resources]: https://pkg.go.dev/github.com/docker/docker/api/types/container#Resources
+type Resources struct {
+	// Applicable to all platforms
+	CPUShares int64 `json:"CpuShares"` // CPU shares (relative weight vs. other containers)
+	Memory    int64 // Memory limit (in bytes)
+	NanoCPUs  int64 `json:"NanoCpus"` // CPU quota in units of 10<sup>-9</sup> CPUs.
+
+	// Applicable to UNIX platforms
+	BlkioWeight    uint16          // Block IO weight (relative weight vs. other containers)
+	CpusetCpus     string          // CpusetCpus 0-2, 0,1
+	Devices        []DeviceMapping // List of devices to map inside the container
+	MemorySwap     int64           // Total memory usage (memory + swap); set `-1` to enable unlimited swap
+	OomKillDisable *bool           // Whether to disable OOM Killer or not
+	PidsLimit      *int64          // Setting PidsLimit to 0 or -1 sets unlimited; nil means unchanged.
+	Ulimits        []*Ulimit       // List of ulimits to be set in the container
+}
+
+// DeviceMapping represents the device mapping between the host and the container.
+type DeviceMapping struct {
+	PathOnHost        string // PathOnHost is the device path on the host, e.g. "/dev/net/tun"
+	PathInContainer   string // PathInContainer is the device path inside the container
+	CgroupPermissions string // CgroupPermissions is a combination of r (read), w (write) and m (mknod), e.g. "rwm"
+}
+
+// Ulimit is a human friendly version of Rlimit.
+type Ulimit struct {
+	Name string // Name is the resource name without the RLIMIT_ prefix, e.g. "nofile"
+	Hard int64  // Hard is the hard limit
+	Soft int64  // Soft is the soft limit
+}
+
+// validate checks hc for invalid combinations of options that the daemon
+// would otherwise reject with an opaque error.
+func (hc *HostConfig) validate() error {
+	if hc == nil {
+		return nil
+	}
+	if err := hc.RestartPolicy.validate(); err != nil {
+		return err
+	}
+	if hc.AutoRemove && !hc.RestartPolicy.IsNone() {
+		return fmt.Errorf("invalid host config: AutoRemove cannot be combined with restart policy %q", hc.RestartPolicy.Name)
+	}
+	for _, m := range hc.Mounts {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+	}
+	if hc.NetworkMode.IsContainer() {
+		if hc.NetworkMode.ConnectedContainer() == "" {
+			return errors.New("invalid host config: network mode 'container:' requires a container name or ID")
+		}
+		// The network namespace, and thus its ports and DNS configuration, is owned by the other container.
+		switch {
+		case len(hc.PortBindings) > 0 || hc.PublishAllPorts:
+			return errors.New("invalid host config: port publishing cannot be combined with container network mode")
+		case len(hc.DNS) > 0 || len(hc.DNSOptions) > 0 || len(hc.DNSSearch) > 0:
+			return errors.New("invalid host config: DNS settings cannot be combined with container network mode")
+		case len(hc.ExtraHosts) > 0:
+			return errors.New("invalid host config: extra hosts cannot be combined with container network mode")
+		}
+	}
+	return nil
+}
+
+// RestartPolicyMode represents the restart policy of a container.
+type RestartPolicyMode string
+
+// Possible RestartPolicyMode values.
+const (
+	RestartPolicyDisabled      RestartPolicyMode = "no"             // Never restart the container (default)
+	RestartPolicyAlways        RestartPolicyMode = "always"         // Always restart the container when it exits
+	RestartPolicyOnFailure     RestartPolicyMode = "on-failure"     // Restart the container when it exits with a non-zero exit code
+	RestartPolicyUnlessStopped RestartPolicyMode = "unless-stopped" // Like always, but not if the container was stopped before the daemon restarted
+)
+
+// RestartPolicy represents the restart policy of the container.
+type RestartPolicy struct {
+	Name              RestartPolicyMode
+	MaximumRetryCount int // Maximum number of restarts; only used with RestartPolicyOnFailure
+}
+
+// IsNone indicates whether the container has the "no" restart policy.
+// This means the container will not automatically restart when exiting.
+func (rp *RestartPolicy) IsNone() bool {
+	return rp.Name == RestartPolicyDisabled || rp.Name == ""
+}
+
+// IsAlways indicates whether the container has the "always" restart policy.
+// This means the container will automatically restart regardless of the exit status.
+func (rp *RestartPolicy) IsAlways() bool {
+	return rp.Name == RestartPolicyAlways
+}
+
+// IsOnFailure indicates whether the container has the "on-failure" restart policy.
+// This means the container will automatically restart of exiting with a non-zero exit status.
+func (rp *RestartPolicy) IsOnFailure() bool {
+	return rp.Name == RestartPolicyOnFailure
+}
+
+// IsUnlessStopped indicates whether the container has the
+// "unless-stopped" restart policy. This means the container will
+// automatically restart unless user has put it to stopped state.
+func (rp *RestartPolicy) IsUnlessStopped() bool {
+	return rp.Name == RestartPolicyUnlessStopped
+}
+
+func (rp *RestartPolicy) validate() error {
+	switch rp.Name {
+	case RestartPolicyAlways, RestartPolicyUnlessStopped, RestartPolicyDisabled, "":
+		if rp.MaximumRetryCount != 0 {
+			return fmt.Errorf("invalid restart policy: maximum retry count can only be used with '%s'", RestartPolicyOnFailure)
+		}
+	case RestartPolicyOnFailure:
+		if rp.MaximumRetryCount < 0 {
+			return errors.New("invalid restart policy: maximum retry count cannot be negative")
+		}
+	default:
+		return fmt.Errorf("invalid restart policy: unknown policy '%s'; use one of '%s', '%s', '%s', or '%s'",
+			rp.Name, RestartPolicyDisabled, RestartPolicyAlways, RestartPolicyOnFailure, RestartPolicyUnlessStopped)
+	}
+	return nil
+}
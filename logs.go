@@ -0,0 +1,236 @@
+package container
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogLine is a single line of output from a container's log stream.
+type LogLine struct {
+	Stream    StdType   // Stream is the stream the line was written to: Stdout or Stderr
+	Timestamp time.Time // Timestamp is the time the line was written; zero unless LogsOptions.Timestamps is set
+	Text      string    // Text is the line without the trailing newline
+}
+
+// ContainerLogLines returns an iterator over the lines of a container's logs.
+// It calls ContainerLogs and parses the resulting stream with ReadLogLines,
+// closing the stream when the iteration stops. If options.Timestamps is set,
+// the Timestamp of each line is set to the time the line was written.
+func (c *Container) ContainerLogLines(ctx context.Context, containerID string, options LogsOptions) iter.Seq2[LogLine, error] {
+	return func(yield func(LogLine, error) bool) {
+		stream, err := c.ContainerLogs(ctx, containerID, options)
+		if err != nil {
+			yield(LogLine{}, err)
+			return
+		}
+		defer func() { _ = stream.Close() }()
+
+		for line, err := range ReadLogLines(stream, options.Timestamps) {
+			if !yield(line, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ReadLogLines returns an iterator over the lines of a log stream read from src,
+// such as the stream returned by ContainerLogs. The stream is demultiplexed as
+// described for StdCopy; if it is not multiplexed, all lines are attributed to
+// Stdout. A final line without a trailing newline is also returned.
+//
+// If timestamps is true, each message is expected to start with an RFC 3339
+// timestamp followed by a space, as produced by LogsOptions.Timestamps.
+// The daemon timestamps each part of a line that was logged in several parts;
+// the parts are joined and the line gets the timestamp of its first part.
+// In the raw stream of a container using a TTY, where parts are not framed,
+// a timestamp followed by a space inside a line starts a new part.
+//
+// The iteration stops after the first error.
+func ReadLogLines(src io.Reader, timestamps bool) iter.Seq2[LogLine, error] {
+	return func(yield func(LogLine, error) bool) {
+		br := bufio.NewReader(src)
+
+		// Buffer partial lines per stream, since the daemon may split
+		// long lines across several messages.
+		var lines [Stderr + 1]lineBuffer
+		add := func(stream StdType, msg []byte) bool {
+			if err := lines[stream].add(msg, timestamps); err != nil {
+				yield(LogLine{}, err)
+				return false
+			}
+			return true
+		}
+
+		if isRawStream(br) {
+			// Raw stream from a container using a TTY.
+			for {
+				text, err := br.ReadBytes('\n')
+				if len(text) > 0 {
+					for _, msg := range splitRawMessages(text, timestamps) {
+						if !add(Stdout, msg) {
+							return
+						}
+					}
+					if !yield(lines[Stdout].flush(Stdout), nil) {
+						return
+					}
+				}
+				if err != nil {
+					if !errors.Is(err, io.EOF) {
+						yield(LogLine{}, err)
+					}
+					return
+				}
+			}
+		}
+
+		var header [stdHeaderLen]byte
+		for {
+			if _, err := io.ReadFull(br, header[:]); err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(LogLine{}, fmt.Errorf("reading frame header: %w", err))
+					return
+				}
+				for stream := range lines {
+					if lines[stream].pending && !yield(lines[stream].flush(StdType(stream)), nil) {
+						return
+					}
+				}
+				return
+			}
+
+			stream := StdType(header[0])
+			payload := make([]byte, binary.BigEndian.Uint32(header[stdSizeIndex:]))
+			if _, err := io.ReadFull(br, payload); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				yield(LogLine{}, fmt.Errorf("reading frame: %w", err))
+				return
+			}
+			switch stream {
+			case Stdin:
+				stream = Stdout
+			case Stdout, Stderr:
+			case Systemerr:
+				yield(LogLine{}, fmt.Errorf("error from daemon in stream: %s", payload))
+				return
+			default:
+				yield(LogLine{}, fmt.Errorf("unrecognized stream type: %d", header[0]))
+				return
+			}
+
+			// Each frame holds one or more messages, each ending a line
+			// except possibly the last.
+			for len(payload) > 0 {
+				msg := payload
+				if i := bytes.IndexByte(payload, '\n'); i >= 0 {
+					msg = payload[:i+1]
+				}
+				payload = payload[len(msg):]
+				if !add(stream, msg) {
+					return
+				}
+				if msg[len(msg)-1] == '\n' && !yield(lines[stream].flush(stream), nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// rawTimestampRegexp matches a timestamp followed by a space, as prefixed to
+// each message by LogsOptions.Timestamps.
+var rawTimestampRegexp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}) `)
+
+// splitRawMessages splits a line read from a raw stream into the messages
+// it was logged as, each starting with a timestamp if timestamps is true.
+func splitRawMessages(text []byte, timestamps bool) [][]byte {
+	if !timestamps {
+		return [][]byte{text}
+	}
+	var msgs [][]byte
+	start := 0
+	for _, loc := range rawTimestampRegexp.FindAllIndex(text, -1) {
+		if loc[0] > start {
+			msgs = append(msgs, text[start:loc[0]])
+			start = loc[0]
+		}
+	}
+	return append(msgs, text[start:])
+}
+
+// lineBuffer joins the messages of a line.
+type lineBuffer struct {
+	text      []byte
+	timestamp time.Time // timestamp of the first message of the line
+	pending   bool      // true if text holds the start of a line
+}
+
+// add appends the message msg to the line, first removing its leading
+// timestamp if timestamps is true.
+func (b *lineBuffer) add(msg []byte, timestamps bool) error {
+	if timestamps {
+		ts, rest, err := cutTimestamp(msg)
+		if err != nil {
+			return err
+		}
+		if !b.pending {
+			b.timestamp = ts
+		}
+		msg = rest
+	}
+	b.text = append(b.text, msg...)
+	b.pending = true
+	return nil
+}
+
+// flush returns the buffered line written to stream and resets the buffer.
+func (b *lineBuffer) flush(stream StdType) LogLine {
+	text := strings.TrimSuffix(string(b.text), "\n")
+	text = strings.TrimSuffix(text, "\r")
+	line := LogLine{Stream: stream, Timestamp: b.timestamp, Text: text}
+	*b = lineBuffer{text: b.text[:0]}
+	return line
+}
+
+// cutTimestamp parses the timestamp at the start of msg and returns it
+// along with the rest of msg after the separating space.
+func cutTimestamp(msg []byte) (time.Time, []byte, error) {
+	end := bytes.IndexAny(msg, " \n")
+	if end < 0 {
+		end = len(msg)
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(msg[:end]))
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("malformed log line timestamp: %w", err)
+	}
+	return t, bytes.TrimPrefix(msg[end:], []byte(" ")), nil
+}
+
+// isRawStream reports whether the stream read by br is the raw output of a
+// container using a TTY rather than a multiplexed stream. It only waits for
+// more data while the bytes buffered so far may still start a frame header,
+// so that a short line from an idle TTY container is not held back.
+func isRawStream(br *bufio.Reader) bool {
+	for n := 1; n <= stdHeaderLen; n++ {
+		b, err := br.Peek(max(n, min(br.Buffered(), stdHeaderLen)))
+		if len(b) > 0 && !isStdHeader(b) {
+			return true
+		}
+		if err != nil || len(b) == stdHeaderLen {
+			return false
+		}
+		n = len(b)
+	}
+	return false
+}
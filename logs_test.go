@@ -0,0 +1,244 @@
+package container_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relab/container"
+)
+
+func TestReadLogLines(t *testing.T) {
+	ts1 := time.Date(2025, 10, 17, 12, 0, 0, 123456789, time.UTC)
+	ts2 := ts1.Add(time.Second)
+	tests := []struct {
+		name       string
+		src        []byte
+		timestamps bool
+		want       []container.LogLine
+		wantErr    string
+	}{
+		{name: "Empty", src: nil},
+		{
+			name: "Multiplexed",
+			src:  concat(frame(1, "out1\nout2\n"), frame(2, "err1\n")),
+			want: []container.LogLine{
+				{Stream: container.Stdout, Text: "out1"},
+				{Stream: container.Stdout, Text: "out2"},
+				{Stream: container.Stderr, Text: "err1"},
+			},
+		},
+		{
+			name: "SplitLines",
+			src:  concat(frame(1, "hel"), frame(2, "oops\n"), frame(1, "lo\nwor"), frame(1, "ld")),
+			want: []container.LogLine{
+				{Stream: container.Stderr, Text: "oops"},
+				{Stream: container.Stdout, Text: "hello"},
+				{Stream: container.Stdout, Text: "world"},
+			},
+		},
+		{
+			name:       "Timestamps",
+			src:        concat(frame(1, ts1.Format(time.RFC3339Nano)+" started\n"), frame(2, ts2.Format(time.RFC3339Nano)+" failed: x y\n")),
+			timestamps: true,
+			want: []container.LogLine{
+				{Stream: container.Stdout, Timestamp: ts1, Text: "started"},
+				{Stream: container.Stderr, Timestamp: ts2, Text: "failed: x y"},
+			},
+		},
+		{
+			name:       "SplitTimestamps",
+			src:        concat(frame(1, ts1.Format(time.RFC3339Nano)+" part1"), frame(2, ts1.Format(time.RFC3339Nano)+" oops\n"), frame(1, ts2.Format(time.RFC3339Nano)+" part2\n")),
+			timestamps: true,
+			want: []container.LogLine{
+				{Stream: container.Stderr, Timestamp: ts1, Text: "oops"},
+				{Stream: container.Stdout, Timestamp: ts1, Text: "part1part2"},
+			},
+		},
+		{
+			name:       "TTYSplitTimestamps",
+			src:        []byte(ts1.Format(time.RFC3339Nano) + " part1" + ts2.Format(time.RFC3339Nano) + " part2\r\n" + ts2.Format(time.RFC3339Nano) + " next\r\n"),
+			timestamps: true,
+			want: []container.LogLine{
+				{Stream: container.Stdout, Timestamp: ts1, Text: "part1part2"},
+				{Stream: container.Stdout, Timestamp: ts2, Text: "next"},
+			},
+		},
+		{
+			name: "TTY",
+			src:  []byte("line 1\r\nline 2\r\npartial"),
+			want: []container.LogLine{
+				{Stream: container.Stdout, Text: "line 1"},
+				{Stream: container.Stdout, Text: "line 2"},
+				{Stream: container.Stdout, Text: "partial"},
+			},
+		},
+		{
+			name:       "MalformedTimestamp",
+			src:        frame(1, "no timestamp\n"),
+			timestamps: true,
+			wantErr:    "malformed log line timestamp: ",
+		},
+		{
+			name:    "Systemerr",
+			src:     concat(frame(1, "ok\n"), frame(3, "boom")),
+			want:    []container.LogLine{{Stream: container.Stdout, Text: "ok"}},
+			wantErr: "error from daemon in stream: boom",
+		},
+		{
+			name:    "TruncatedFrame",
+			src:     frame(1, "hello\n")[:10],
+			wantErr: "reading frame: unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []container.LogLine
+			var gotErr error
+			for line, err := range container.ReadLogLines(bytes.NewReader(tt.src), tt.timestamps) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, line)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadLogLines() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantErr == "" && gotErr != nil {
+				t.Errorf("ReadLogLines() error = %v, want nil", gotErr)
+			}
+			if tt.wantErr != "" && (gotErr == nil || !strings.HasPrefix(gotErr.Error(), tt.wantErr)) {
+				t.Errorf("ReadLogLines() error = %v, want prefix %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadLogLinesBreak(t *testing.T) {
+	src := concat(frame(1, "a\n"), frame(1, "b\n"), frame(1, "c\n"))
+	var got []string
+	for line, err := range container.ReadLogLines(bytes.NewReader(src), false) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, line.Text)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestContainerLogsOptions(t *testing.T) {
+	since := time.Date(2025, 10, 17, 12, 0, 0, 5, time.UTC)
+	tests := []struct {
+		name    string
+		options container.LogsOptions
+		want    url.Values
+	}{
+		{
+			name:    "Defaults",
+			options: container.LogsOptions{ShowStdout: true},
+			want:    url.Values{"stdout": {"1"}},
+		},
+		{
+			name:    "SinceUntil",
+			options: container.LogsOptions{Since: container.TimeAt(since), Until: container.TimeAt(since.Add(90 * time.Second))},
+			want:    url.Values{"since": {"1760702400.000000005"}, "until": {"1760702490.000000005"}},
+		},
+		{
+			name:    "TailNone",
+			options: container.LogsOptions{Follow: true, Tail: container.TailLines(0)},
+			want:    url.Values{"follow": {"1"}, "tail": {"0"}},
+		},
+		{
+			name:    "TailAll",
+			options: container.LogsOptions{Tail: container.TailAll()},
+			want:    url.Values{},
+		},
+		{
+			name:    "Tail",
+			options: container.LogsOptions{ShowStderr: true, Timestamps: true, Tail: container.TailLines(100)},
+			want:    url.Values{"stderr": {"1"}, "timestamps": {"1"}, "tail": {"100"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query()
+			})
+			stream, err := c.ContainerLogs(t.Context(), "replica-1", tt.options)
+			if err != nil {
+				t.Fatalf("Failed to get container logs: %v", err)
+			}
+			_ = stream.Close()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerLogsSinceAgo(t *testing.T) {
+	var got string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("since")
+	})
+	before := time.Now()
+	stream, err := c.ContainerLogs(t.Context(), "replica-1", container.LogsOptions{Since: container.TimeAgo(time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to get container logs: %v", err)
+	}
+	_ = stream.Close()
+
+	secs, nsecs, ok := strings.Cut(got, ".")
+	if !ok || len(nsecs) != 9 {
+		t.Fatalf("since = %q, want seconds.nanoseconds", got)
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := before.Add(-time.Hour).Unix(); sec < want || sec > want+5 {
+		t.Errorf("since = %q, want about %d", got, want)
+	}
+}
+
+func TestReadLogLinesShortTTYFollow(t *testing.T) {
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { _ = pw.Close() })
+	go func() {
+		// The stream stays open after a TTY line shorter than a frame header.
+		_, _ = pw.Write([]byte("ready\r\n"))
+	}()
+
+	lines := make(chan container.LogLine, 1)
+	go func() {
+		for line, err := range container.ReadLogLines(pr, false) {
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			lines <- line
+			return
+		}
+	}()
+	select {
+	case line := <-lines:
+		if want := (container.LogLine{Stream: container.Stdout, Text: "ready"}); line != want {
+			t.Errorf("line = %+v, want %+v", line, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReadLogLines did not yield short TTY line before the stream was closed")
+	}
+}
@@ -1,5 +1,11 @@
 package mount
 
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
 // The struct definitions in this file are largely copied from the Docker API
 // types, but may have been simplified for our use case.
 // See the original [Mount].
@@ -31,6 +37,161 @@ type Mount struct {
 	// Source specifies the name of the mount. Depending on mount type, this
 	// may be a volume name or a host path, or even ignored.
 	// Source is not supported for tmpfs (must be an empty value)
-	Source string `json:",omitempty"`
-	Target string `json:",omitempty"`
+	Source      string      `json:",omitempty"`
+	Target      string      `json:",omitempty"`
+	ReadOnly    bool        `json:",omitempty"` // attempts recursive read-only if possible
+	Consistency Consistency `json:",omitempty"`
+
+	BindOptions   *BindOptions   `json:",omitempty"`
+	VolumeOptions *VolumeOptions `json:",omitempty"`
+	ImageOptions  *ImageOptions  `json:",omitempty"`
+	TmpfsOptions  *TmpfsOptions  `json:",omitempty"`
+}
+
+// Propagation represents the propagation of a mount.
+type Propagation string
+
+const (
+	// PropagationRPrivate RPRIVATE
+	PropagationRPrivate Propagation = "rprivate"
+	// PropagationPrivate PRIVATE
+	PropagationPrivate Propagation = "private"
+	// PropagationRShared RSHARED
+	PropagationRShared Propagation = "rshared"
+	// PropagationShared SHARED
+	PropagationShared Propagation = "shared"
+	// PropagationRSlave RSLAVE
+	PropagationRSlave Propagation = "rslave"
+	// PropagationSlave SLAVE
+	PropagationSlave Propagation = "slave"
+)
+
+// Propagations is the list of all valid mount propagations
+var Propagations = []Propagation{
+	PropagationRPrivate,
+	PropagationPrivate,
+	PropagationRShared,
+	PropagationShared,
+	PropagationRSlave,
+	PropagationSlave,
+}
+
+// Consistency represents the consistency requirements of a mount.
+type Consistency string
+
+const (
+	// ConsistencyFull guarantees bind mount-like consistency
+	ConsistencyFull Consistency = "consistent"
+	// ConsistencyCached mounts can cache read data and FS structure
+	ConsistencyCached Consistency = "cached"
+	// ConsistencyDelegated mounts can cache read and written data and structure
+	ConsistencyDelegated Consistency = "delegated"
+	// ConsistencyDefault provides "consistent" behavior unless overridden
+	ConsistencyDefault Consistency = "default"
+)
+
+// BindOptions defines options specific to mounts of type "bind".
+type BindOptions struct {
+	Propagation  Propagation `json:",omitempty"`
+	NonRecursive bool        `json:",omitempty"`
+	// CreateMountpoint creates the host path if it does not exist.
+	CreateMountpoint bool `json:",omitempty"`
+}
+
+// VolumeOptions represents the options for a mount of type volume.
+type VolumeOptions struct {
+	NoCopy       bool              `json:",omitempty"` // NoCopy disables copying the image's content at Target into a new volume
+	Labels       map[string]string `json:",omitempty"`
+	Subpath      string            `json:",omitempty"` // Subpath mounts a subdirectory of the volume, relative to its root
+	DriverConfig *Driver           `json:",omitempty"`
+}
+
+// ImageOptions represents the options for a mount of type image.
+type ImageOptions struct {
+	Subpath string `json:",omitempty"` // Subpath mounts a subdirectory of the image, relative to its root
+}
+
+// Driver represents a volume driver.
+type Driver struct {
+	Name    string            `json:",omitempty"`
+	Options map[string]string `json:",omitempty"`
+}
+
+// TmpfsOptions defines options specific to mounts of type "tmpfs".
+type TmpfsOptions struct {
+	// Size sets the size of the tmpfs, in bytes.
+	//
+	// This will be converted to an operating system specific value
+	// depending on the host. For example, on linux, it will be converted to
+	// use a 'k', 'm' or 'g' syntax. BSD, though not widely supported with
+	// docker, uses a straight byte value.
+	//
+	// Percentages are not supported.
+	SizeBytes int64 `json:",omitempty"`
+	// Mode of the tmpfs upon creation
+	Mode os.FileMode `json:",omitempty"`
+}
+
+// Validate checks m for errors that the daemon would otherwise reject,
+// such as a missing target, a source for a tmpfs mount, or options that
+// do not match the mount type.
+func (m Mount) Validate() error {
+	if m.Target == "" {
+		return m.invalid(errors.New("field Target must not be empty"))
+	}
+	if m.BindOptions != nil && m.Type != TypeBind {
+		return m.invalid(errors.New("BindOptions must not be specified"))
+	}
+	if m.VolumeOptions != nil && m.Type != TypeVolume {
+		return m.invalid(errors.New("VolumeOptions must not be specified"))
+	}
+	if m.ImageOptions != nil && m.Type != TypeImage {
+		return m.invalid(errors.New("ImageOptions must not be specified"))
+	}
+	if m.TmpfsOptions != nil && m.Type != TypeTmpfs {
+		return m.invalid(errors.New("TmpfsOptions must not be specified"))
+	}
+
+	switch m.Type {
+	case TypeBind, TypeNamedPipe, TypeImage:
+		if m.Source == "" {
+			return m.invalid(errors.New("field Source must not be empty"))
+		}
+	case TypeVolume, TypeCluster:
+		// Source may be empty for anonymous volumes.
+	case TypeTmpfs:
+		if m.Source != "" {
+			return m.invalid(errors.New("field Source must be empty"))
+		}
+		if m.TmpfsOptions != nil && m.TmpfsOptions.SizeBytes < 0 {
+			return m.invalid(fmt.Errorf("invalid tmpfs size %d", m.TmpfsOptions.SizeBytes))
+		}
+	case "":
+		return errors.New("invalid mount config: mount type must not be empty")
+	default:
+		return fmt.Errorf("invalid mount config: mount type unknown: %q", m.Type)
+	}
+
+	if m.BindOptions != nil && m.BindOptions.Propagation != "" && !validPropagation(m.BindOptions.Propagation) {
+		return m.invalid(fmt.Errorf("invalid propagation %q", m.BindOptions.Propagation))
+	}
+	switch m.Consistency {
+	case "", ConsistencyFull, ConsistencyCached, ConsistencyDelegated, ConsistencyDefault:
+	default:
+		return m.invalid(fmt.Errorf("invalid consistency %q", m.Consistency))
+	}
+	return nil
+}
+
+func (m Mount) invalid(err error) error {
+	return fmt.Errorf("invalid mount config for type %q: %w", m.Type, err)
+}
+
+func validPropagation(p Propagation) bool {
+	for _, valid := range Propagations {
+		if p == valid {
+			return true
+		}
+	}
+	return false
 }
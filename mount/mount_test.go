@@ -0,0 +1,68 @@
+package mount_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/relab/container/mount"
+)
+
+func TestMountValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mount   mount.Mount
+		wantErr bool
+	}{
+		{name: "Bind", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", ReadOnly: true}},
+		{name: "BindOptions", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", BindOptions: &mount.BindOptions{Propagation: mount.PropagationRShared, CreateMountpoint: true}}},
+		{name: "AnonymousVolume", mount: mount.Mount{Type: mount.TypeVolume, Target: "/data"}},
+		{name: "VolumeOptions", mount: mount.Mount{Type: mount.TypeVolume, Source: "data", Target: "/data", VolumeOptions: &mount.VolumeOptions{NoCopy: true, Subpath: "logs"}}},
+		{name: "Tmpfs", mount: mount.Mount{Type: mount.TypeTmpfs, Target: "/tmp", TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 64 << 20, Mode: 0o1777}}},
+		{name: "Image", mount: mount.Mount{Type: mount.TypeImage, Source: "alpine", Target: "/alpine", ImageOptions: &mount.ImageOptions{Subpath: "etc"}}},
+		{name: "Consistency", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", Consistency: mount.ConsistencyCached}},
+
+		{name: "MissingType", mount: mount.Mount{Source: "/data", Target: "/data"}, wantErr: true},
+		{name: "UnknownType", mount: mount.Mount{Type: "overlay", Source: "/data", Target: "/data"}, wantErr: true},
+		{name: "MissingTarget", mount: mount.Mount{Type: mount.TypeBind, Source: "/data"}, wantErr: true},
+		{name: "BindWithoutSource", mount: mount.Mount{Type: mount.TypeBind, Target: "/data"}, wantErr: true},
+		{name: "ImageWithoutSource", mount: mount.Mount{Type: mount.TypeImage, Target: "/alpine"}, wantErr: true},
+		{name: "TmpfsWithSource", mount: mount.Mount{Type: mount.TypeTmpfs, Source: "/tmp", Target: "/tmp"}, wantErr: true},
+		{name: "NegativeTmpfsSize", mount: mount.Mount{Type: mount.TypeTmpfs, Target: "/tmp", TmpfsOptions: &mount.TmpfsOptions{SizeBytes: -1}}, wantErr: true},
+		{name: "BindOptionsOnVolume", mount: mount.Mount{Type: mount.TypeVolume, Target: "/data", BindOptions: &mount.BindOptions{}}, wantErr: true},
+		{name: "VolumeOptionsOnBind", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", VolumeOptions: &mount.VolumeOptions{}}, wantErr: true},
+		{name: "TmpfsOptionsOnVolume", mount: mount.Mount{Type: mount.TypeVolume, Target: "/data", TmpfsOptions: &mount.TmpfsOptions{}}, wantErr: true},
+		{name: "ImageOptionsOnBind", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", ImageOptions: &mount.ImageOptions{}}, wantErr: true},
+		{name: "UnknownPropagation", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", BindOptions: &mount.BindOptions{Propagation: "shared-ish"}}, wantErr: true},
+		{name: "UnknownConsistency", mount: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", Consistency: "eventual"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mount.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMountJSON(t *testing.T) {
+	m := mount.Mount{
+		Type:     mount.TypeVolume,
+		Source:   "data",
+		Target:   "/data",
+		ReadOnly: true,
+		VolumeOptions: &mount.VolumeOptions{
+			NoCopy:       true,
+			Labels:       map[string]string{"owner": "test"},
+			DriverConfig: &mount.Driver{Name: "local", Options: map[string]string{"type": "nfs"}},
+		},
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Type":"volume","Source":"data","Target":"/data","ReadOnly":true,"VolumeOptions":{"NoCopy":true,"Labels":{"owner":"test"},"DriverConfig":{"Name":"local","Options":{"type":"nfs"}}}}`
+	if got := string(data); got != want {
+		t.Errorf("json.Marshal(Mount) = %s, want %s", got, want)
+	}
+}
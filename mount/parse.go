@@ -0,0 +1,294 @@
+package mount
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The parsers in this file follow the syntax of the docker CLI's --mount and
+// -v/--volume flags, assuming a Linux daemon. See the original [opts/mount.go]
+// and [volume/mounts/linux_parser.go].
+//
+// [opts/mount.go]: https://github.com/docker/cli/blob/master/opts/mount.go
+// [volume/mounts/linux_parser.go]: https://github.com/moby/moby/blob/master/volume/mounts/linux_parser.go
+
+// Parse parses a mount specification in the syntax of the docker --mount
+// flag, e.g. "type=bind,source=/data,target=/data,readonly", into a Mount.
+// The type defaults to "volume" if not specified. The returned mount is
+// validated with [Mount.Validate].
+func Parse(spec string) (Mount, error) {
+	fields, err := csv.NewReader(strings.NewReader(spec)).Read()
+	if err != nil {
+		return Mount{}, fmt.Errorf("invalid mount spec %q: %w", spec, err)
+	}
+
+	m := Mount{Type: TypeVolume}
+	// Options are collected first and attached after all fields are parsed,
+	// so that the type may appear anywhere in the spec.
+	var (
+		bind   BindOptions
+		volume VolumeOptions
+		driver Driver
+		image  ImageOptions
+		tmpfs  TmpfsOptions
+
+		hasBind, hasVolume, hasDriver, hasImage, hasTmpfs bool
+	)
+	for _, field := range fields {
+		key, value, hasValue := strings.Cut(field, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		// Boolean options may be given without a value, e.g. "readonly".
+		switch key {
+		case "readonly", "ro", "bind-nonrecursive", "bind-create-mountpoint", "volume-nocopy":
+			b := true
+			if hasValue {
+				if b, err = parseBool(value); err != nil {
+					return Mount{}, fmt.Errorf("invalid value for %s: %s", key, value)
+				}
+			}
+			switch key {
+			case "readonly", "ro":
+				m.ReadOnly = b
+			case "bind-nonrecursive":
+				bind.NonRecursive, hasBind = b, true
+			case "bind-create-mountpoint":
+				bind.CreateMountpoint, hasBind = b, true
+			case "volume-nocopy":
+				volume.NoCopy, hasVolume = b, true
+			}
+			continue
+		}
+		if !hasValue {
+			return Mount{}, fmt.Errorf("invalid field %q: must be a key=value pair", field)
+		}
+
+		switch key {
+		case "type":
+			m.Type = Type(strings.ToLower(value))
+		case "source", "src":
+			m.Source = value
+		case "target", "dst", "destination":
+			m.Target = value
+		case "consistency":
+			m.Consistency = Consistency(strings.ToLower(value))
+		case "bind-propagation":
+			bind.Propagation, hasBind = Propagation(strings.ToLower(value)), true
+		case "volume-subpath":
+			volume.Subpath, hasVolume = value, true
+		case "volume-driver":
+			driver.Name, hasDriver = value, true
+		case "volume-label":
+			k, v, _ := strings.Cut(value, "=")
+			if volume.Labels == nil {
+				volume.Labels = make(map[string]string)
+			}
+			volume.Labels[k], hasVolume = v, true
+		case "volume-opt":
+			k, v, ok := strings.Cut(value, "=")
+			if !ok {
+				return Mount{}, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+			if driver.Options == nil {
+				driver.Options = make(map[string]string)
+			}
+			driver.Options[k], hasDriver = v, true
+		case "image-subpath":
+			image.Subpath, hasImage = value, true
+		case "tmpfs-size":
+			size, err := parseSize(value)
+			if err != nil {
+				return Mount{}, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+			tmpfs.SizeBytes, hasTmpfs = size, true
+		case "tmpfs-mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil {
+				return Mount{}, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+			tmpfs.Mode, hasTmpfs = os.FileMode(mode), true
+		default:
+			return Mount{}, fmt.Errorf("unexpected key %q in %q", key, field)
+		}
+	}
+
+	if hasDriver {
+		volume.DriverConfig, hasVolume = &driver, true
+	}
+	if hasBind {
+		m.BindOptions = &bind
+	}
+	if hasVolume {
+		m.VolumeOptions = &volume
+	}
+	if hasImage {
+		m.ImageOptions = &image
+	}
+	if hasTmpfs {
+		m.TmpfsOptions = &tmpfs
+	}
+	if m.Type == TypeBind {
+		if err := checkLinuxPath(m.Source); err != nil {
+			return Mount{}, fmt.Errorf("invalid mount spec %q: %w", spec, err)
+		}
+		if m.Source != "" && !path.IsAbs(m.Source) {
+			return Mount{}, fmt.Errorf("invalid mount spec %q: bind source %q must be an absolute path", spec, m.Source)
+		}
+	}
+	if err := checkLinuxPath(m.Target); err != nil {
+		return Mount{}, fmt.Errorf("invalid mount spec %q: %w", spec, err)
+	}
+	if err := m.Validate(); err != nil {
+		return Mount{}, err
+	}
+	return m, nil
+}
+
+// volumeNameRegexp matches valid names of named volumes.
+var volumeNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// ParseVolume parses a volume specification in the syntax of the docker -v
+// flag, "[source:]target[:mode]", into a Mount. A source that is an absolute
+// path creates a bind mount; any other source names a volume, and a spec
+// without a source creates an anonymous volume. The mode is a comma-separated
+// list of "ro" or "rw", a consistency, and for bind mounts a propagation or
+// for volumes "nocopy".
+func ParseVolume(spec string) (Mount, error) {
+	if strings.Contains(spec, `\`) || isWindowsDrive(spec) {
+		return Mount{}, fmt.Errorf("invalid volume spec %q: windows-style paths are not supported", spec)
+	}
+
+	var m Mount
+	var mode string
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 1:
+		m.Type, m.Target = TypeVolume, parts[0]
+	case 2:
+		m.Source, m.Target = parts[0], parts[1]
+	case 3:
+		m.Source, m.Target, mode = parts[0], parts[1], parts[2]
+		if mode == "" {
+			return Mount{}, fmt.Errorf("invalid volume spec %q: empty mode", spec)
+		}
+	default:
+		return Mount{}, fmt.Errorf("invalid volume spec %q: too many colons", spec)
+	}
+
+	if m.Target == "" || !path.IsAbs(m.Target) {
+		return Mount{}, fmt.Errorf("invalid volume spec %q: target %q must be an absolute path", spec, m.Target)
+	}
+	if m.Target == "/" {
+		return Mount{}, fmt.Errorf("invalid volume spec %q: target must not be \"/\"", spec)
+	}
+	switch {
+	case m.Type == TypeVolume:
+	case path.IsAbs(m.Source):
+		m.Type = TypeBind
+	case volumeNameRegexp.MatchString(m.Source):
+		m.Type = TypeVolume
+	default:
+		return Mount{}, fmt.Errorf("invalid volume spec %q: %q is not an absolute path or a valid volume name", spec, m.Source)
+	}
+
+	if mode != "" {
+		if err := m.applyVolumeMode(mode); err != nil {
+			return Mount{}, fmt.Errorf("invalid volume spec %q: %w", spec, err)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		return Mount{}, err
+	}
+	return m, nil
+}
+
+// applyVolumeMode applies the comma-separated mode of a -v spec to m.
+func (m *Mount) applyVolumeMode(mode string) error {
+	var seenRW, seenConsistency, seenPropagation, seenNoCopy bool
+	for _, opt := range strings.Split(mode, ",") {
+		switch o := strings.TrimSpace(opt); {
+		case o == "ro" || o == "rw":
+			if seenRW {
+				return fmt.Errorf("duplicate mode %q", o)
+			}
+			seenRW, m.ReadOnly = true, o == "ro"
+		case o == string(ConsistencyFull) || o == string(ConsistencyCached) || o == string(ConsistencyDelegated):
+			if seenConsistency {
+				return fmt.Errorf("duplicate mode %q", o)
+			}
+			seenConsistency, m.Consistency = true, Consistency(o)
+		case validPropagation(Propagation(o)):
+			if m.Type != TypeBind {
+				return fmt.Errorf("propagation mode %q is only supported for bind mounts", o)
+			}
+			if seenPropagation {
+				return fmt.Errorf("duplicate mode %q", o)
+			}
+			seenPropagation = true
+			m.BindOptions = &BindOptions{Propagation: Propagation(o)}
+		case o == "nocopy":
+			if m.Type != TypeVolume {
+				return errors.New(`mode "nocopy" is only supported for volumes`)
+			}
+			if seenNoCopy {
+				return fmt.Errorf("duplicate mode %q", o)
+			}
+			seenNoCopy = true
+			m.VolumeOptions = &VolumeOptions{NoCopy: true}
+		case o == "z" || o == "Z":
+			return fmt.Errorf("SELinux relabeling mode %q cannot be expressed as a mount", o)
+		default:
+			return fmt.Errorf("unknown mode %q", o)
+		}
+	}
+	return nil
+}
+
+// checkLinuxPath returns an error if p looks like a Windows path.
+func checkLinuxPath(p string) error {
+	if strings.Contains(p, `\`) || isWindowsDrive(p) {
+		return fmt.Errorf("windows-style path %q is not supported", p)
+	}
+	return nil
+}
+
+// isWindowsDrive reports whether p starts with a drive letter, e.g. "C:".
+func isWindowsDrive(p string) bool {
+	return len(p) >= 2 && p[1] == ':' &&
+		('a' <= p[0] && p[0] <= 'z' || 'A' <= p[0] && p[0] <= 'Z')
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "true":
+		return true, nil
+	case "0", "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", s)
+}
+
+// parseSize parses a size in bytes with an optional binary unit suffix,
+// e.g. "512", "64m" or "1GiB", as accepted by the docker CLI.
+func parseSize(s string) (int64, error) {
+	num := strings.TrimRightFunc(s, func(r rune) bool {
+		return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z'
+	})
+	unit := strings.ToLower(s[len(num):])
+	unit = strings.TrimSuffix(strings.TrimSuffix(unit, "b"), "i")
+	shift, ok := map[string]uint{"": 0, "k": 10, "m": 20, "g": 30, "t": 40, "p": 50}[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(uint64(1)<<shift)), nil
+}
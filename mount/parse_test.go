@@ -0,0 +1,147 @@
+package mount_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/relab/container/mount"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    mount.Mount
+		wantErr bool
+	}{
+		{
+			spec: "type=bind,source=/data,target=/data,readonly",
+			want: mount.Mount{Type: mount.TypeBind, Source: "/data", Target: "/data", ReadOnly: true},
+		},
+		{
+			spec: "type=bind,src=/data,dst=/data,ro=false,bind-propagation=rshared,bind-nonrecursive,bind-create-mountpoint=true",
+			want: mount.Mount{
+				Type: mount.TypeBind, Source: "/data", Target: "/data",
+				BindOptions: &mount.BindOptions{Propagation: mount.PropagationRShared, NonRecursive: true, CreateMountpoint: true},
+			},
+		},
+		{
+			spec: "target=/data",
+			want: mount.Mount{Type: mount.TypeVolume, Target: "/data"},
+		},
+		{
+			spec: "source=data,destination=/data,volume-nocopy,volume-subpath=logs,volume-label=owner=test,volume-driver=local,volume-opt=type=nfs",
+			want: mount.Mount{
+				Type: mount.TypeVolume, Source: "data", Target: "/data",
+				VolumeOptions: &mount.VolumeOptions{
+					NoCopy:       true,
+					Subpath:      "logs",
+					Labels:       map[string]string{"owner": "test"},
+					DriverConfig: &mount.Driver{Name: "local", Options: map[string]string{"type": "nfs"}},
+				},
+			},
+		},
+		{
+			spec: "type=tmpfs,target=/tmp,tmpfs-size=64m,tmpfs-mode=1777",
+			want: mount.Mount{Type: mount.TypeTmpfs, Target: "/tmp", TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 64 << 20, Mode: 0o1777}},
+		},
+		{
+			spec: "type=tmpfs,target=/tmp,tmpfs-size=1GiB",
+			want: mount.Mount{Type: mount.TypeTmpfs, Target: "/tmp", TmpfsOptions: &mount.TmpfsOptions{SizeBytes: 1 << 30}},
+		},
+		{
+			spec: "type=image,source=alpine:latest,target=/alpine,image-subpath=etc",
+			want: mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/alpine", ImageOptions: &mount.ImageOptions{Subpath: "etc"}},
+		},
+		{
+			spec: "type=npipe,source=/run/docker.sock,target=/run/docker.sock",
+			want: mount.Mount{Type: mount.TypeNamedPipe, Source: "/run/docker.sock", Target: "/run/docker.sock"},
+		},
+		{
+			spec: "type=cluster,source=shared,target=/shared",
+			want: mount.Mount{Type: mount.TypeCluster, Source: "shared", Target: "/shared"},
+		},
+		{
+			spec: `type=bind,"source=/data,with,commas",target=/data,consistency=cached`,
+			want: mount.Mount{Type: mount.TypeBind, Source: "/data,with,commas", Target: "/data", Consistency: mount.ConsistencyCached},
+		},
+
+		{spec: "", wantErr: true},
+		{spec: "type=bind,target=/data", wantErr: true},
+		{spec: "type=bind,source=data,target=/data", wantErr: true},
+		{spec: `type=bind,source=C:\data,target=/data`, wantErr: true},
+		{spec: "type=bind,source=C:/data,target=/data", wantErr: true},
+		{spec: `type=volume,target=C:\data`, wantErr: true},
+		{spec: "type=tmpfs,source=/tmp,target=/tmp", wantErr: true},
+		{spec: "type=tmpfs,target=/tmp,tmpfs-size=lots", wantErr: true},
+		{spec: "type=tmpfs,target=/tmp,tmpfs-mode=999", wantErr: true},
+		{spec: "type=volume,target=/data,bind-propagation=rshared", wantErr: true},
+		{spec: "type=bind,source=/data,target=/data,volume-nocopy", wantErr: true},
+		{spec: "type=overlay,target=/data", wantErr: true},
+		{spec: "type=bind,source=/data,target=/data,readonly=maybe", wantErr: true},
+		{spec: "type=bind,source=/data,target=/data,verbose=1", wantErr: true},
+		{spec: "type=bind,source=/data,/data", wantErr: true},
+		{spec: "target=/data,volume-opt=nfs", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := mount.Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %t", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVolume(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    mount.Mount
+		wantErr bool
+	}{
+		{spec: "/data", want: mount.Mount{Type: mount.TypeVolume, Target: "/data"}},
+		{spec: "/srv/data:/data", want: mount.Mount{Type: mount.TypeBind, Source: "/srv/data", Target: "/data"}},
+		{spec: "/srv/data:/data:ro", want: mount.Mount{Type: mount.TypeBind, Source: "/srv/data", Target: "/data", ReadOnly: true}},
+		{spec: "/srv/data:/data:rw,rslave,delegated", want: mount.Mount{
+			Type: mount.TypeBind, Source: "/srv/data", Target: "/data", Consistency: mount.ConsistencyDelegated,
+			BindOptions: &mount.BindOptions{Propagation: mount.PropagationRSlave},
+		}},
+		{spec: "data:/data", want: mount.Mount{Type: mount.TypeVolume, Source: "data", Target: "/data"}},
+		{spec: "my-data_1.0:/data:ro,nocopy", want: mount.Mount{
+			Type: mount.TypeVolume, Source: "my-data_1.0", Target: "/data", ReadOnly: true,
+			VolumeOptions: &mount.VolumeOptions{NoCopy: true},
+		}},
+
+		{spec: "", wantErr: true},
+		{spec: "data", wantErr: true},
+		{spec: "/srv/data:data", wantErr: true},
+		{spec: "/srv/data:/", wantErr: true},
+		{spec: "./data:/data", wantErr: true},
+		{spec: "d:/data", wantErr: true},
+		{spec: `C:\data:/data`, wantErr: true},
+		{spec: `C:\data:C:\data`, wantErr: true},
+		{spec: "c:/data:/data", wantErr: true},
+		{spec: `/srv/data:/data\sub`, wantErr: true},
+		{spec: "/srv/data:/data:", wantErr: true},
+		{spec: "data:/data:", wantErr: true},
+		{spec: "/srv/data:/data:ro:extra", wantErr: true},
+		{spec: "/srv/data:/data:ro,rw", wantErr: true},
+		{spec: "/srv/data:/data:nocopy", wantErr: true},
+		{spec: "data:/data:rshared", wantErr: true},
+		{spec: "/srv/data:/data:z", wantErr: true},
+		{spec: "/srv/data:/data:fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := mount.ParseVolume(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVolume(%q) error = %v, wantErr %t", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVolume(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
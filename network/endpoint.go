@@ -1,6 +1,39 @@
 package network
 
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the originals [EndpointSettings] and [EndpointIPAMConfig].
+//
+// [EndpointSettings]: https://github.com/moby/moby/blob/master/api/types/network/endpoint.go
+// [EndpointIPAMConfig]: https://github.com/moby/moby/blob/master/api/types/network/endpoint.go
+
 // EndpointSettings stores the network endpoint details
 type EndpointSettings struct {
-	Aliases []string // Aliases holds the list of extra, user-specified DNS names for this endpoint.
+	// Configurations
+	IPAMConfig *EndpointIPAMConfig `json:",omitempty"`
+	Links      []string
+	Aliases    []string // Aliases holds the list of extra, user-specified DNS names for this endpoint.
+	MacAddress string   // MacAddress may be used to specify a MAC address when the container is created.
+	DriverOpts map[string]string
+
+	// Operational data
+	NetworkID           string
+	EndpointID          string
+	Gateway             string
+	IPAddress           string
+	IPPrefixLen         int
+	IPv6Gateway         string
+	GlobalIPv6Address   string
+	GlobalIPv6PrefixLen int
+
+	// DNSNames holds all the (non fully qualified) DNS names associated to this
+	// endpoint. First entry is used to generate PTR records.
+	DNSNames []string
+}
+
+// EndpointIPAMConfig represents IPAM configurations for the endpoint
+type EndpointIPAMConfig struct {
+	IPv4Address  string   `json:",omitempty"`
+	IPv6Address  string   `json:",omitempty"`
+	LinkLocalIPs []string `json:",omitempty"`
 }
@@ -0,0 +1,75 @@
+package container
+
+import "strings"
+
+// NetworkMode represents the network mode of a container: one of the
+// predefined modes, "container:<name|id>" to share the network namespace of
+// another container, or the name of a user-defined network.
+type NetworkMode string
+
+// Predefined network modes.
+const (
+	NetworkModeDefault NetworkMode = "default" // NetworkModeDefault uses the daemon's default network, normally bridge
+	NetworkModeBridge  NetworkMode = "bridge"  // NetworkModeBridge connects the container to the default bridge network
+	NetworkModeHost    NetworkMode = "host"    // NetworkModeHost uses the host's network namespace
+	NetworkModeNone    NetworkMode = "none"    // NetworkModeNone disables networking, except for the loopback interface
+)
+
+const networkModeContainerPrefix = "container:"
+
+// NetworkModeContainer returns the network mode that shares the network
+// namespace of the container with the given name or ID. This is useful for
+// sidecar containers that need to reach the main container on localhost.
+func NetworkModeContainer(nameOrID string) NetworkMode {
+	return NetworkMode(networkModeContainerPrefix + nameOrID)
+}
+
+// IsDefault indicates whether container uses the default network stack.
+func (n NetworkMode) IsDefault() bool {
+	return n == NetworkModeDefault || n == ""
+}
+
+// IsBridge indicates whether container uses the bridge network stack.
+func (n NetworkMode) IsBridge() bool {
+	return n == NetworkModeBridge
+}
+
+// IsHost indicates whether container uses the host network stack.
+func (n NetworkMode) IsHost() bool {
+	return n == NetworkModeHost
+}
+
+// IsNone indicates whether container isn't using a network stack.
+func (n NetworkMode) IsNone() bool {
+	return n == NetworkModeNone
+}
+
+// IsContainer indicates whether container uses a container network stack.
+func (n NetworkMode) IsContainer() bool {
+	return strings.HasPrefix(string(n), networkModeContainerPrefix)
+}
+
+// ConnectedContainer is the name or ID of the container whose network stack
+// is shared, or the empty string if the network mode is not container mode.
+func (n NetworkMode) ConnectedContainer() string {
+	if !n.IsContainer() {
+		return ""
+	}
+	return strings.TrimPrefix(string(n), networkModeContainerPrefix)
+}
+
+// IsUserDefined indicates user-created network.
+func (n NetworkMode) IsUserDefined() bool {
+	return !n.IsDefault() && !n.IsBridge() && !n.IsHost() && !n.IsNone() && !n.IsContainer()
+}
+
+// NetworkName returns the name of the network stack.
+func (n NetworkMode) NetworkName() string {
+	switch {
+	case n.IsDefault():
+		return string(NetworkModeDefault)
+	case n.IsContainer():
+		return "container"
+	}
+	return string(n)
+}
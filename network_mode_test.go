@@ -0,0 +1,48 @@
+package container_test
+
+import (
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestNetworkMode(t *testing.T) {
+	tests := []struct {
+		mode               container.NetworkMode
+		isDefault          bool
+		isBridge           bool
+		isHost             bool
+		isNone             bool
+		isContainer        bool
+		isUserDefined      bool
+		connectedContainer string
+		networkName        string
+	}{
+		{mode: "", isDefault: true, networkName: "default"},
+		{mode: container.NetworkModeDefault, isDefault: true, networkName: "default"},
+		{mode: container.NetworkModeBridge, isBridge: true, networkName: "bridge"},
+		{mode: container.NetworkModeHost, isHost: true, networkName: "host"},
+		{mode: container.NetworkModeNone, isNone: true, networkName: "none"},
+		{mode: container.NetworkModeContainer("replica-1"), isContainer: true, connectedContainer: "replica-1", networkName: "container"},
+		{mode: "replicas", isUserDefined: true, networkName: "replicas"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			m := tt.mode
+			got := []bool{m.IsDefault(), m.IsBridge(), m.IsHost(), m.IsNone(), m.IsContainer(), m.IsUserDefined()}
+			want := []bool{tt.isDefault, tt.isBridge, tt.isHost, tt.isNone, tt.isContainer, tt.isUserDefined}
+			names := []string{"IsDefault", "IsBridge", "IsHost", "IsNone", "IsContainer", "IsUserDefined"}
+			for i := range names {
+				if got[i] != want[i] {
+					t.Errorf("%s() = %t, want %t", names[i], got[i], want[i])
+				}
+			}
+			if got := m.ConnectedContainer(); got != tt.connectedContainer {
+				t.Errorf("ConnectedContainer() = %q, want %q", got, tt.connectedContainer)
+			}
+			if got := m.NetworkName(); got != tt.networkName {
+				t.Errorf("NetworkName() = %q, want %q", got, tt.networkName)
+			}
+		})
+	}
+}
@@ -1,18 +1,113 @@
 package container
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
+	"time"
+
+	"github.com/relab/container/filters"
 )
 
 // The struct definitions in this file are largely copied from the Docker API
 // types, but may have been simplified for our use case.
-// See the original [RemoveOptions], [LogsOptions], and [StopOptions].
+// See the original [AttachOptions], [ListOptions], [RemoveOptions], [LogsOptions], and [StopOptions].
 //
+// [AttachOptions]: https://github.com/moby/moby/blob/master/api/types/container/options.go#L22
+// [ListOptions]: https://github.com/moby/moby/blob/master/api/types/container/options.go#L45
 // [RemoveOptions]: https://github.com/moby/moby/blob/master/api/types/container/options.go#L34
 // [LogsOptions]: https://github.com/moby/moby/blob/master/api/types/container/options.go#L58
 // [StopOptions]: https://github.com/moby/moby/blob/master/api/types/container/config.go#L18
 
+// AttachOptions holds parameters to attach to a container.
+type AttachOptions struct {
+	Stream     bool   // Stream attached streams; without it, only logs are returned if Logs is set
+	Stdin      bool   // Stdin attaches to the container's standard input
+	Stdout     bool   // Stdout attaches to the container's standard output
+	Stderr     bool   // Stderr attaches to the container's standard error
+	DetachKeys string // DetachKeys overrides the key sequence for detaching, e.g. "ctrl-p,ctrl-q"
+	Logs       bool   // Logs replays the container's previous output
+}
+
+func (o AttachOptions) url(containerID string) string {
+	query := url.Values{}
+	if o.Stream {
+		query.Set("stream", "1")
+	}
+	if o.Stdin {
+		query.Set("stdin", "1")
+	}
+	if o.Stdout {
+		query.Set("stdout", "1")
+	}
+	if o.Stderr {
+		query.Set("stderr", "1")
+	}
+	if o.DetachKeys != "" {
+		query.Set("detachKeys", o.DetachKeys)
+	}
+	if o.Logs {
+		query.Set("logs", "1")
+	}
+	u := url.URL{Scheme: "http", Host: "localhost", Path: "/containers/" + containerID + "/attach", RawQuery: query.Encode()}
+	return u.String()
+}
+
+// ListOptions holds parameters to list containers with.
+type ListOptions struct {
+	Size    bool         // Size returns the container sizes (SizeRw and SizeRootFs)
+	All     bool         // All returns all containers; by default only running containers are returned
+	Limit   int          // Limit returns at most this many of the most recently created containers
+	Filters filters.Args // Filters restricts the list to matching containers, e.g. "before", "health" or "exited"
+}
+
+// ListFilters holds the most common filters to apply when listing containers.
+// Containers must match at least one of the values of each non-empty filter.
+// Use its Args method to build ListOptions.Filters, and add any other filter
+// supported by the daemon to the returned [filters.Args].
+type ListFilters struct {
+	Label    []string // Label matches containers with a label "key" or "key=value"
+	Name     []string // Name matches (part of) container names
+	Status   []string // Status matches container states: created, restarting, running, removing, paused, exited or dead
+	Ancestor []string // Ancestor matches containers created from an image or its descendants, e.g. "alpine:latest"
+	Network  []string // Network matches containers connected to a network, by name or ID
+}
+
+// Args returns the filters as [filters.Args].
+func (f ListFilters) Args() filters.Args {
+	var args filters.Args
+	for key, values := range map[string][]string{
+		"label":    f.Label,
+		"name":     f.Name,
+		"status":   f.Status,
+		"ancestor": f.Ancestor,
+		"network":  f.Network,
+	} {
+		for _, value := range values {
+			args.Add(key, value)
+		}
+	}
+	return args
+}
+
+func (o ListOptions) url() (string, error) {
+	query := url.Values{}
+	if o.All {
+		query.Set("all", "1")
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Size {
+		query.Set("size", "1")
+	}
+	if err := o.Filters.SetQuery(query); err != nil {
+		return "", err
+	}
+	u := url.URL{Scheme: "http", Host: "localhost", Path: "/containers/json", RawQuery: query.Encode()}
+	return u.String(), nil
+}
+
 // RemoveOptions holds parameters to remove containers.
 type RemoveOptions struct {
 	RemoveVolumes bool
@@ -39,13 +134,16 @@ func (o RemoveOptions) url(containerID string) string {
 type LogsOptions struct {
 	ShowStdout bool
 	ShowStderr bool
+	Since      LogTime // Since only returns logs written at or after this time
+	Until      LogTime // Until only returns logs written before this time
 	Timestamps bool
 	Follow     bool
-	Tail       string
+	Tail       Tail // Tail only returns this many lines from the end of the logs
 	Details    bool
 }
 
 func (o LogsOptions) url(containerID string) string {
+	now := time.Now()
 	query := url.Values{}
 	if o.ShowStdout {
 		query.Set("stdout", "1")
@@ -53,6 +151,12 @@ func (o LogsOptions) url(containerID string) string {
 	if o.ShowStderr {
 		query.Set("stderr", "1")
 	}
+	if !o.Since.IsZero() {
+		query.Set("since", o.Since.encode(now))
+	}
+	if !o.Until.IsZero() {
+		query.Set("until", o.Until.encode(now))
+	}
 	if o.Timestamps {
 		query.Set("timestamps", "1")
 	}
@@ -62,12 +166,77 @@ func (o LogsOptions) url(containerID string) string {
 	if o.Details {
 		query.Set("details", "1")
 	}
-	query.Set("tail", o.Tail)
+	if o.Tail.set {
+		query.Set("tail", o.Tail.String())
+	}
 
 	u := url.URL{Scheme: "http", Host: "localhost", Path: "/containers/" + containerID + "/logs", RawQuery: query.Encode()}
 	return u.String()
 }
 
+// LogTime is a point in time used to limit the logs returned by ContainerLogs,
+// either an absolute time created with TimeAt, or a time relative to when the
+// logs are requested created with TimeAgo. The zero value means no limit.
+type LogTime struct {
+	t        time.Time
+	ago      time.Duration
+	relative bool
+}
+
+// TimeAt returns the LogTime for the absolute time t.
+func TimeAt(t time.Time) LogTime {
+	return LogTime{t: t}
+}
+
+// TimeAgo returns the LogTime for the duration d before the logs are requested.
+func TimeAgo(d time.Duration) LogTime {
+	return LogTime{ago: d, relative: true}
+}
+
+// IsZero reports whether lt is the zero value, meaning no limit.
+func (lt LogTime) IsZero() bool {
+	return !lt.relative && lt.t.IsZero()
+}
+
+// encode returns lt as a Unix timestamp in the "seconds.nanoseconds" format
+// expected by the daemon, resolving relative times against now.
+func (lt LogTime) encode(now time.Time) string {
+	t := lt.t
+	if lt.relative {
+		t = now.Add(-lt.ago)
+	}
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// Tail is the number of lines to return from the end of the logs.
+// The zero value returns all lines, the same as TailAll.
+type Tail struct {
+	lines int
+	set   bool
+}
+
+// TailAll returns the Tail for all lines of the logs.
+func TailAll() Tail {
+	return Tail{}
+}
+
+// TailLines returns the Tail for the last n lines of the logs.
+// A negative n returns all lines.
+func TailLines(n int) Tail {
+	if n < 0 {
+		return TailAll()
+	}
+	return Tail{lines: n, set: true}
+}
+
+// String returns the value of the tail query parameter: "all" or the number of lines.
+func (t Tail) String() string {
+	if !t.set {
+		return "all"
+	}
+	return strconv.Itoa(t.lines)
+}
+
 // StopOptions holds the options to stop or restart a container.
 type StopOptions struct {
 	// Signal (optional) is the signal to send to the container to (gracefully)
@@ -86,7 +255,7 @@ type StopOptions struct {
 	Timeout *int `json:",omitempty"`
 }
 
-func (o StopOptions) url() string {
+func (o StopOptions) url(containerID, action string) string {
 	query := url.Values{}
 	if o.Timeout != nil {
 		query.Set("t", strconv.Itoa(*o.Timeout))
@@ -94,6 +263,6 @@ func (o StopOptions) url() string {
 	if o.Signal != "" {
 		query.Set("signal", o.Signal)
 	}
-	u := url.URL{Scheme: "http", Host: "localhost", Path: "/stop", RawQuery: query.Encode()}
+	u := url.URL{Scheme: "http", Host: "localhost", Path: "/containers/" + containerID + "/" + action, RawQuery: query.Encode()}
 	return u.String()
 }
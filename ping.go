@@ -0,0 +1,27 @@
+package container
+
+import "net/http"
+
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the original [Ping].
+//
+// [Ping]: https://github.com/moby/moby/blob/master/api/types/types.go
+
+// PingResponse holds the information returned by the daemon in response
+// to a ping request.
+type PingResponse struct {
+	APIVersion     string // APIVersion is the daemon's maximum supported API version
+	OSType         string // OSType is the daemon's operating system, e.g. "linux"
+	Experimental   bool   // Experimental reports if the daemon runs with experimental features enabled
+	BuilderVersion string // BuilderVersion is the daemon's default builder version
+}
+
+func newPingResponse(header http.Header) PingResponse {
+	return PingResponse{
+		APIVersion:     header.Get("API-Version"),
+		OSType:         header.Get("OSType"),
+		Experimental:   header.Get("Docker-Experimental") == "true",
+		BuilderVersion: header.Get("Builder-Version"),
+	}
+}
@@ -0,0 +1,186 @@
+package container
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The functions in this file are largely modeled after the docker
+// go-connections package. See the original [nat.go].
+//
+// [nat.go]: https://github.com/docker/go-connections/blob/main/nat/nat.go
+
+// NewPort returns the Port for the given protocol and port number or range,
+// e.g. NewPort("udp", "53") or NewPort("tcp", "8000-8010").
+// The protocol must be tcp, udp or sctp; it defaults to tcp if empty.
+func NewPort(proto, port string) (Port, error) {
+	proto, err := validProto(proto)
+	if err != nil {
+		return "", err
+	}
+	start, end, err := parsePortRange(port, 1)
+	if err != nil {
+		return "", err
+	}
+	if start == end {
+		return Port(strconv.Itoa(start) + "/" + proto), nil
+	}
+	return Port(fmt.Sprintf("%d-%d/%s", start, end, proto)), nil
+}
+
+// Proto returns the protocol of p, e.g. "tcp" for "80/tcp".
+// It returns "tcp" if p has no protocol.
+func (p Port) Proto() string {
+	if _, proto, ok := strings.Cut(string(p), "/"); ok {
+		return proto
+	}
+	return "tcp"
+}
+
+// Port returns the port number or range of p, e.g. "80" for "80/tcp".
+func (p Port) Port() string {
+	port, _, _ := strings.Cut(string(p), "/")
+	return port
+}
+
+// Int returns the port number of p, e.g. 80 for "80/tcp", or the first
+// port of a range. It returns 0 if p is not a valid port.
+func (p Port) Int() int {
+	start, _, err := parsePortRange(p.Port(), 1)
+	if err != nil {
+		return 0
+	}
+	return start
+}
+
+// ParsePortSpecs parses port specifications in the syntax of the docker
+// --publish flag and returns the exposed ports and their host bindings.
+// Each spec has the form "[[hostIP:]hostPort:]containerPort[/proto]", where
+// the ports may be ranges, e.g. "127.0.0.1:8080:80/tcp", "9000-9005:9000-9005",
+// "[::1]::53/udp" or "8080". A spec without a host port exposes the container
+// port and lets the daemon pick a host port.
+func ParsePortSpecs(specs []string) (PortSet, PortMap, error) {
+	exposedPorts := make(PortSet, len(specs))
+	bindings := make(PortMap)
+	for _, spec := range specs {
+		if err := parsePortSpec(spec, exposedPorts, bindings); err != nil {
+			return nil, nil, err
+		}
+	}
+	return exposedPorts, bindings, nil
+}
+
+func parsePortSpec(spec string, exposedPorts PortSet, bindings PortMap) error {
+	rawPort, proto, _ := strings.Cut(spec, "/")
+	proto, err := validProto(proto)
+	if err != nil {
+		return fmt.Errorf("invalid port spec %q: %w", spec, err)
+	}
+
+	var hostIP, hostPort, containerPort string
+	if strings.HasPrefix(rawPort, "[") {
+		// IPv6 host addresses are enclosed in brackets, e.g. "[::1]:8080:80".
+		end := strings.LastIndex(rawPort, "]")
+		if end < 0 {
+			return fmt.Errorf("invalid port spec %q: missing ']' in host IP", spec)
+		}
+		hostIP, rawPort = rawPort[1:end], strings.TrimPrefix(rawPort[end+1:], ":")
+		parts := strings.Split(rawPort, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid port spec %q", spec)
+		}
+		hostPort, containerPort = parts[0], parts[1]
+	} else {
+		switch parts := strings.Split(rawPort, ":"); len(parts) {
+		case 1:
+			containerPort = parts[0]
+		case 2:
+			hostPort, containerPort = parts[0], parts[1]
+		case 3:
+			hostIP, hostPort, containerPort = parts[0], parts[1], parts[2]
+		default:
+			return fmt.Errorf("invalid port spec %q: too many colons", spec)
+		}
+	}
+	if hostIP != "" {
+		if _, err := netip.ParseAddr(hostIP); err != nil {
+			return fmt.Errorf("invalid port spec %q: invalid host IP %q", spec, hostIP)
+		}
+	}
+
+	start, end, err := parsePortRange(containerPort, 1)
+	if err != nil {
+		return fmt.Errorf("invalid port spec %q: invalid container port: %w", spec, err)
+	}
+	var hostStart, hostEnd int
+	if hostPort != "" {
+		hostStart, hostEnd, err = parsePortRange(hostPort, 0)
+		if err != nil {
+			return fmt.Errorf("invalid port spec %q: invalid host port: %w", spec, err)
+		}
+		// A single container port may be bound to any port in a host range;
+		// otherwise the ranges are paired up port by port.
+		if start != end && end-start != hostEnd-hostStart {
+			return fmt.Errorf("invalid port spec %q: container and host port ranges differ in size", spec)
+		}
+	}
+
+	for i := range end - start + 1 {
+		port := Port(strconv.Itoa(start+i) + "/" + proto)
+		exposedPorts[port] = struct{}{}
+		binding := PortBinding{HostIP: hostIP}
+		switch {
+		case hostPort == "":
+		case start == end && hostStart != hostEnd:
+			binding.HostPort = fmt.Sprintf("%d-%d", hostStart, hostEnd)
+		default:
+			binding.HostPort = strconv.Itoa(hostStart + i)
+		}
+		if !slices.Contains(bindings[port], binding) {
+			bindings[port] = append(bindings[port], binding)
+		}
+	}
+	return nil
+}
+
+// validProto returns the lower-cased protocol, or tcp if proto is empty,
+// and an error if proto is not tcp, udp or sctp.
+func validProto(proto string) (string, error) {
+	switch proto = strings.ToLower(proto); proto {
+	case "":
+		return "tcp", nil
+	case "tcp", "udp", "sctp":
+		return proto, nil
+	}
+	return "", fmt.Errorf("invalid protocol %q: must be tcp, udp or sctp", proto)
+}
+
+// parsePortRange parses a port number, e.g. "80", or an inclusive range,
+// e.g. "8000-8010", whose ports are in the range [minPort, 65535].
+func parsePortRange(ports string, minPort int) (start, end int, err error) {
+	first, last, isRange := strings.Cut(ports, "-")
+	if start, err = parsePortNumber(first, minPort); err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	if end, err = parsePortNumber(last, minPort); err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("invalid port range %q: end before start", ports)
+	}
+	return start, end, nil
+}
+
+func parsePortNumber(port string, minPort int) (int, error) {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < minPort || n > 65535 {
+		return 0, fmt.Errorf("invalid port %q: must be a number between %d and 65535", port, minPort)
+	}
+	return n, nil
+}
@@ -0,0 +1,142 @@
+package container_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestNewPort(t *testing.T) {
+	tests := []struct {
+		proto, port string
+		want        container.Port
+		wantErr     bool
+	}{
+		{proto: "tcp", port: "80", want: "80/tcp"},
+		{proto: "", port: "22", want: "22/tcp"},
+		{proto: "UDP", port: "53", want: "53/udp"},
+		{proto: "sctp", port: "8000-8010", want: "8000-8010/sctp"},
+		{proto: "icmp", port: "80", wantErr: true},
+		{proto: "tcp", port: "0", wantErr: true},
+		{proto: "tcp", port: "65536", wantErr: true},
+		{proto: "tcp", port: "http", wantErr: true},
+		{proto: "tcp", port: "8010-8000", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := container.NewPort(tt.proto, tt.port)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewPort(%q, %q) error = %v, wantErr %t", tt.proto, tt.port, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NewPort(%q, %q) = %q, want %q", tt.proto, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestPortMethods(t *testing.T) {
+	tests := []struct {
+		port  container.Port
+		proto string
+		num   string
+		int   int
+	}{
+		{port: "80/tcp", proto: "tcp", num: "80", int: 80},
+		{port: "53/udp", proto: "udp", num: "53", int: 53},
+		{port: "8000-8010/sctp", proto: "sctp", num: "8000-8010", int: 8000},
+		{port: "22", proto: "tcp", num: "22", int: 22},
+		{port: "ssh/tcp", proto: "tcp", num: "ssh", int: 0},
+	}
+	for _, tt := range tests {
+		if got := tt.port.Proto(); got != tt.proto {
+			t.Errorf("Port(%q).Proto() = %q, want %q", tt.port, got, tt.proto)
+		}
+		if got := tt.port.Port(); got != tt.num {
+			t.Errorf("Port(%q).Port() = %q, want %q", tt.port, got, tt.num)
+		}
+		if got := tt.port.Int(); got != tt.int {
+			t.Errorf("Port(%q).Int() = %d, want %d", tt.port, got, tt.int)
+		}
+	}
+}
+
+func TestParsePortSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		specs        []string
+		wantExposed  container.PortSet
+		wantBindings container.PortMap
+		wantErr      bool
+	}{
+		{
+			name:         "ContainerPort",
+			specs:        []string{"80"},
+			wantExposed:  container.PortSet{"80/tcp": {}},
+			wantBindings: container.PortMap{"80/tcp": {{}}},
+		},
+		{
+			name:         "HostIPAndPort",
+			specs:        []string{"127.0.0.1:8080:80/tcp"},
+			wantExposed:  container.PortSet{"80/tcp": {}},
+			wantBindings: container.PortMap{"80/tcp": {{HostIP: "127.0.0.1", HostPort: "8080"}}},
+		},
+		{
+			name:        "Ranges",
+			specs:       []string{"9000-9002:9000-9002"},
+			wantExposed: container.PortSet{"9000/tcp": {}, "9001/tcp": {}, "9002/tcp": {}},
+			wantBindings: container.PortMap{
+				"9000/tcp": {{HostPort: "9000"}},
+				"9001/tcp": {{HostPort: "9001"}},
+				"9002/tcp": {{HostPort: "9002"}},
+			},
+		},
+		{
+			name:         "HostRangeForSinglePort",
+			specs:        []string{"8000-8010:80"},
+			wantExposed:  container.PortSet{"80/tcp": {}},
+			wantBindings: container.PortMap{"80/tcp": {{HostPort: "8000-8010"}}},
+		},
+		{
+			name:         "IPv6HostIPWithoutHostPort",
+			specs:        []string{"[::1]::53/udp"},
+			wantExposed:  container.PortSet{"53/udp": {}},
+			wantBindings: container.PortMap{"53/udp": {{HostIP: "::1"}}},
+		},
+		{
+			name:        "MultipleBindings",
+			specs:       []string{"127.0.0.1:2222:22", "[::1]:2222:22", "127.0.0.1:2222:22", "5000/sctp"},
+			wantExposed: container.PortSet{"22/tcp": {}, "5000/sctp": {}},
+			wantBindings: container.PortMap{
+				"22/tcp":    {{HostIP: "127.0.0.1", HostPort: "2222"}, {HostIP: "::1", HostPort: "2222"}},
+				"5000/sctp": {{}},
+			},
+		},
+		{name: "InvalidProtocol", specs: []string{"80/icmp"}, wantErr: true},
+		{name: "InvalidContainerPort", specs: []string{"8080:0"}, wantErr: true},
+		{name: "InvalidHostPort", specs: []string{"70000:80"}, wantErr: true},
+		{name: "InvalidHostIP", specs: []string{"localhost:8080:80"}, wantErr: true},
+		{name: "MismatchedRanges", specs: []string{"9000-9001:9000-9002"}, wantErr: true},
+		{name: "ReversedRange", specs: []string{"9002-9000"}, wantErr: true},
+		{name: "TooManyColons", specs: []string{"1:2:3:4"}, wantErr: true},
+		{name: "UnclosedIPv6", specs: []string{"[::1:8080:80"}, wantErr: true},
+		{name: "Empty", specs: []string{""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exposed, bindings, err := container.ParsePortSpecs(tt.specs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePortSpecs(%q) error = %v, wantErr %t", tt.specs, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(exposed, tt.wantExposed) {
+				t.Errorf("ParsePortSpecs(%q) exposed = %v, want %v", tt.specs, exposed, tt.wantExposed)
+			}
+			if !reflect.DeepEqual(bindings, tt.wantBindings) {
+				t.Errorf("ParsePortSpecs(%q) bindings = %v, want %v", tt.specs, bindings, tt.wantBindings)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Common values for HostConfig.SecurityOpt.
+const (
+	// SeccompUnconfined disables the seccomp profile, allowing all system calls.
+	SeccompUnconfined = "seccomp=unconfined"
+	// AppArmorUnconfined disables the AppArmor profile.
+	AppArmorUnconfined = "apparmor=unconfined"
+	// NoNewPrivileges prevents the container processes from gaining new privileges,
+	// e.g. through setuid binaries.
+	NoNewPrivileges = "no-new-privileges"
+	// LabelDisable disables SELinux labeling for the container.
+	LabelDisable = "label=disable"
+)
+
+// SeccompProfile returns the HostConfig.SecurityOpt value that applies the
+// given seccomp profile, which must be a JSON document. The daemon expects the
+// profile's content, not the path of a file holding it; see SeccompProfileFile.
+func SeccompProfile(profile []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, profile); err != nil {
+		return "", fmt.Errorf("invalid seccomp profile: %w", err)
+	}
+	return "seccomp=" + buf.String(), nil
+}
+
+// SeccompProfileFile returns the HostConfig.SecurityOpt value that applies
+// the seccomp profile in the JSON file at path.
+func SeccompProfileFile(path string) (string, error) {
+	profile, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading seccomp profile: %w", err)
+	}
+	return SeccompProfile(profile)
+}
+
+// AppArmorProfile returns the HostConfig.SecurityOpt value that applies the
+// named AppArmor profile, which must be loaded on the docker host.
+func AppArmorProfile(name string) string {
+	return "apparmor=" + name
+}
+
+// UsernsMode represents the user namespace mode of a container.
+type UsernsMode string
+
+// IsHost indicates whether the container uses the host's user namespace.
+func (n UsernsMode) IsHost() bool {
+	return n == "host"
+}
+
+// IsPrivate indicates whether the container uses a private user namespace.
+func (n UsernsMode) IsPrivate() bool {
+	return !n.IsHost()
+}
@@ -0,0 +1,98 @@
+package container
+
+import "time"
+
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the originals [State] and [Health].
+//
+// [State]: https://github.com/moby/moby/blob/master/api/types/container/state.go
+// [Health]: https://github.com/moby/moby/blob/master/api/types/container/health.go
+
+// ContainerState is a string representation of the container's current state.
+type ContainerState string
+
+// Possible ContainerState values.
+const (
+	StateCreated    ContainerState = "created"    // StateCreated indicates the container is created, but not (yet) started.
+	StateRunning    ContainerState = "running"    // StateRunning indicates that the container is running.
+	StatePaused     ContainerState = "paused"     // StatePaused indicates that the container's current state is paused.
+	StateRestarting ContainerState = "restarting" // StateRestarting indicates that the container is currently restarting.
+	StateRemoving   ContainerState = "removing"   // StateRemoving indicates that the container is being removed.
+	StateExited     ContainerState = "exited"     // StateExited indicates that the container exited.
+	StateDead       ContainerState = "dead"       // StateDead indicates that the container failed to be deleted. Containers in this state are attempted to be cleaned up when the daemon restarts.
+)
+
+// State stores container's running state
+// it's part of ContainerJSONBase and returned by "inspect" command
+type State struct {
+	Status     ContainerState // String representation of the container state. Can be one of "created", "running", "paused", "restarting", "removing", "exited", or "dead"
+	Running    bool
+	Paused     bool
+	Restarting bool
+	OOMKilled  bool
+	Dead       bool
+	Pid        int
+	ExitCode   int
+	Error      string
+	StartedAt  string  // StartedAt is the time the container was last started, in RFC 3339 format
+	FinishedAt string  // FinishedAt is the time the container last exited, in RFC 3339 format
+	Health     *Health `json:",omitempty"`
+}
+
+// StartedTime returns the time the container was last started, or the zero
+// time if it has never been started.
+func (s *State) StartedTime() time.Time {
+	return parseStateTime(s.StartedAt)
+}
+
+// FinishedTime returns the time the container last exited, or the zero
+// time if it has never exited.
+func (s *State) FinishedTime() time.Time {
+	return parseStateTime(s.FinishedAt)
+}
+
+// HealthStatus returns the container's health status, or NoHealthcheck if
+// the container has no healthcheck configured.
+func (s *State) HealthStatus() HealthStatus {
+	if s.Health == nil {
+		return NoHealthcheck
+	}
+	return s.Health.Status
+}
+
+// parseStateTime parses an RFC 3339 timestamp reported by the daemon,
+// returning the zero time if it is empty or malformed.
+func parseStateTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// HealthStatus is a string representation of the container's health.
+type HealthStatus string
+
+// Health states
+const (
+	NoHealthcheck HealthStatus = "none"      // Indicates there is no healthcheck
+	Starting      HealthStatus = "starting"  // Starting indicates that the container is not yet ready
+	Healthy       HealthStatus = "healthy"   // Healthy indicates that the container is running correctly
+	Unhealthy     HealthStatus = "unhealthy" // Unhealthy indicates that the container has a problem
+)
+
+// Health stores information about the container's healthcheck results
+type Health struct {
+	Status        HealthStatus         // Status is one of [Starting], [Healthy] or [Unhealthy].
+	FailingStreak int                  // FailingStreak is the number of consecutive failures
+	Log           []*HealthcheckResult // Log contains the last few results (oldest first)
+}
+
+// HealthcheckResult stores information about a single run of a healthcheck probe
+type HealthcheckResult struct {
+	Start    time.Time // Start is the time this check started
+	End      time.Time // End is the time this check ended
+	ExitCode int       // ExitCode meanings: 0=healthy, 1=unhealthy, 2=reserved (considered unhealthy), else=error running probe
+	Output   string    // Output from last check
+}
@@ -0,0 +1,135 @@
+package container
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StdType is the type of a standard stream in a multiplexed stream,
+// as given by the first byte of each frame header.
+type StdType byte
+
+const (
+	Stdin     StdType = iota // Stdin represents standard input stream type.
+	Stdout                   // Stdout represents standard output stream type.
+	Stderr                   // Stderr represents standard error stream type.
+	Systemerr                // Systemerr represents errors originating from the system that make it into the multiplexed stream.
+)
+
+const (
+	stdHeaderLen = 8 // length of the frame header of a multiplexed stream
+	stdSizeIndex = 4 // index of the big endian uint32 frame size in the header
+)
+
+// StdCopy demultiplexes a multiplexed stream from src, such as the output of
+// ContainerLogs or ExecStart, writing stdout frames to dstout and stderr frames
+// to dsterr, until src returns io.EOF. See [Container.ContainerLogs] for a
+// description of the stream format.
+//
+// If the stream does not start with a valid frame header, it is assumed to
+// be the raw output of a container or command using a TTY, and it is copied
+// to dstout unchanged.
+//
+// StdCopy returns the number of bytes written to dstout and dsterr, excluding
+// frame headers. If the daemon reports an error in the stream (Systemerr),
+// StdCopy returns it as an error.
+//
+// This mirrors [stdcopy.StdCopy] from the Docker API.
+//
+// [stdcopy.StdCopy]: https://pkg.go.dev/github.com/docker/docker/pkg/stdcopy#StdCopy
+func StdCopy(dstout, dsterr io.Writer, src io.Reader) (written int64, err error) {
+	var header [stdHeaderLen]byte
+	for first := true; ; first = false {
+		var n int
+		if first {
+			n, err = readFirstHeader(src, header[:])
+			if n > 0 && !isStdHeader(header[:n]) {
+				return copyRaw(dstout, header[:n], src)
+			}
+		} else {
+			_, err = io.ReadFull(src, header[:])
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return written, nil
+			}
+			return written, fmt.Errorf("reading frame header: %w", err)
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[stdSizeIndex:]))
+		var dst io.Writer
+		switch StdType(header[0]) {
+		case Stdin, Stdout:
+			dst = dstout
+		case Stderr:
+			dst = dsterr
+		case Systemerr:
+			var msg bytes.Buffer
+			if _, err := io.CopyN(&msg, src, size); err != nil {
+				return written, fmt.Errorf("reading error from daemon: %w", err)
+			}
+			return written, fmt.Errorf("error from daemon in stream: %s", msg.String())
+		default:
+			return written, fmt.Errorf("unrecognized stream type: %d", header[0])
+		}
+
+		n64, err := io.CopyN(dst, src, size)
+		written += n64
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return written, fmt.Errorf("reading frame: %w", err)
+		}
+	}
+}
+
+// readFirstHeader reads the first frame header of a stream into header like
+// io.ReadFull, but returns as soon as the bytes read so far cannot start a
+// frame header. Thus, short raw TTY output is not held back waiting for more
+// data, e.g. when following the output of an idle container.
+func readFirstHeader(src io.Reader, header []byte) (n int, err error) {
+	for n < len(header) {
+		var m int
+		m, err = src.Read(header[n:])
+		n += m
+		if n > 0 && !isStdHeader(header[:n]) {
+			return n, nil
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) && n > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// isStdHeader reports whether b is a prefix of a valid frame header:
+// a known stream type followed by three zero bytes.
+func isStdHeader(b []byte) bool {
+	if StdType(b[0]) > Systemerr {
+		return false
+	}
+	for _, c := range b[1:min(len(b), stdSizeIndex)] {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// copyRaw writes prefix followed by the rest of src to dst.
+func copyRaw(dst io.Writer, prefix []byte, src io.Reader) (written int64, err error) {
+	n, err := dst.Write(prefix)
+	written = int64(n)
+	if err != nil {
+		return written, err
+	}
+	n64, err := io.Copy(dst, src)
+	return written + n64, err
+}
@@ -0,0 +1,177 @@
+package container_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relab/container"
+)
+
+func TestStdCopy(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         []byte
+		wantStdout  string
+		wantStderr  string
+		wantWritten int64
+		wantErr     string
+	}{
+		{name: "Empty", src: nil},
+		{
+			name:        "Stdout",
+			src:         frame(1, "hello\n"),
+			wantStdout:  "hello\n",
+			wantWritten: 6,
+		},
+		{
+			name:        "Interleaved",
+			src:         concat(frame(1, "out1\n"), frame(2, "err1\n"), frame(1, "out2\n"), frame(2, "")),
+			wantStdout:  "out1\nout2\n",
+			wantStderr:  "err1\n",
+			wantWritten: 15,
+		},
+		{
+			name:        "Stdin",
+			src:         frame(0, "input"),
+			wantStdout:  "input",
+			wantWritten: 5,
+		},
+		{
+			name:        "LargeFrame",
+			src:         frame(2, strings.Repeat("x", 100_000)),
+			wantStderr:  strings.Repeat("x", 100_000),
+			wantWritten: 100_000,
+		},
+		{
+			name:        "Systemerr",
+			src:         concat(frame(1, "partial"), frame(3, "container not running")),
+			wantStdout:  "partial",
+			wantWritten: 7,
+			wantErr:     "error from daemon in stream: container not running",
+		},
+		{
+			name:        "TTY",
+			src:         []byte("$ ls -l\r\ntotal 0\r\n"),
+			wantStdout:  "$ ls -l\r\ntotal 0\r\n",
+			wantWritten: 18,
+		},
+		{
+			name:        "ShortTTY",
+			src:         []byte("ok\n"),
+			wantStdout:  "ok\n",
+			wantWritten: 3,
+		},
+		{
+			name:    "TruncatedHeader",
+			src:     frame(1, "hello")[:5],
+			wantErr: "reading frame header: unexpected EOF",
+		},
+		{
+			name:        "TruncatedFrame",
+			src:         frame(2, "hello")[:10],
+			wantStderr:  "he",
+			wantWritten: 2,
+			wantErr:     "reading frame: unexpected EOF",
+		},
+		{
+			name:        "UnknownStreamType",
+			src:         concat(frame(1, "a"), frame(4, "b")),
+			wantStdout:  "a",
+			wantWritten: 1,
+			wantErr:     "unrecognized stream type: 4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			// Use a reader that returns one byte at a time to exercise short reads.
+			written, err := container.StdCopy(&stdout, &stderr, &oneByteReader{r: bytes.NewReader(tt.src)})
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("StdCopy() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("StdCopy() error = %v, want %q", err, tt.wantErr)
+			}
+			if written != tt.wantWritten {
+				t.Errorf("StdCopy() written = %d, want %d", written, tt.wantWritten)
+			}
+			if got := stdout.String(); got != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", got, tt.wantStdout)
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestStdCopyWriteError(t *testing.T) {
+	errWrite := errors.New("write failed")
+	_, err := container.StdCopy(errWriter{errWrite}, io.Discard, bytes.NewReader(frame(1, "hello")))
+	if !errors.Is(err, errWrite) {
+		t.Errorf("StdCopy() error = %v, want %v", err, errWrite)
+	}
+}
+
+func concat(frames ...[]byte) []byte {
+	return bytes.Join(frames, nil)
+}
+
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p[:1])
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestStdCopyShortTTYFollow(t *testing.T) {
+	pr, pw := io.Pipe()
+	stdout := make(chanWriter, 1)
+	done := make(chan error, 1)
+	go func() {
+		_, err := container.StdCopy(stdout, io.Discard, pr)
+		done <- err
+	}()
+
+	// The stream stays open after a TTY write shorter than a frame header.
+	if _, err := pw.Write([]byte("ok\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-stdout:
+		if string(got) != "ok\r\n" {
+			t.Errorf("stdout = %q, want %q", got, "ok\r\n")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("StdCopy did not forward short TTY output before the stream was closed")
+	}
+
+	_ = pw.Close()
+	if err := <-done; err != nil {
+		t.Errorf("StdCopy() error = %v, want nil", err)
+	}
+}
+
+// chanWriter sends a copy of each write to the channel.
+type chanWriter chan []byte
+
+func (w chanWriter) Write(p []byte) (int, error) {
+	w <- bytes.Clone(p)
+	return len(p), nil
+}
@@ -0,0 +1,110 @@
+package container_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestContainerStop(t *testing.T) {
+	timeout := 5
+	tests := []struct {
+		name    string
+		id      string
+		status  int
+		options container.StopOptions
+		wantURI string
+		wantErr func(error) bool
+	}{
+		{name: "Stopped", id: "replica-1", status: http.StatusNoContent, wantURI: "/v1.47/containers/replica-1/stop"},
+		{
+			name: "WithOptions", id: "replica-1", status: http.StatusNoContent,
+			options: container.StopOptions{Signal: "SIGINT", Timeout: &timeout},
+			wantURI: "/v1.47/containers/replica-1/stop?signal=SIGINT&t=5",
+		},
+		{name: "AlreadyStopped", id: "replica-1", status: http.StatusNotModified, wantURI: "/v1.47/containers/replica-1/stop"},
+		{name: "NotFound", id: "replica-2", status: http.StatusNotFound, wantURI: "/v1.47/containers/replica-2/stop", wantErr: container.IsNotFound},
+		{name: "ServerError", id: "replica-1", status: http.StatusInternalServerError, wantURI: "/v1.47/containers/replica-1/stop", wantErr: func(err error) bool { return err != nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotURI string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				gotURI = r.URL.RequestURI()
+				w.WriteHeader(tt.status)
+			})
+			err := c.ContainerStop(t.Context(), tt.id, tt.options)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("ContainerStop() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Errorf("ContainerStop() = %v, want typed error", err)
+			}
+			if gotURI != tt.wantURI {
+				t.Errorf("request URI = %q, want %q", gotURI, tt.wantURI)
+			}
+		})
+	}
+}
+
+func TestContainerRestart(t *testing.T) {
+	var gotMethod, gotURI string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotURI = r.Method, r.URL.RequestURI()
+		if r.URL.Path != "/v1.47/containers/replica-1/restart" {
+			http.Error(w, `{"message":"No such container"}`, http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	timeout := 0
+	if err := c.ContainerRestart(t.Context(), "replica-1", container.StopOptions{Timeout: &timeout}); err != nil {
+		t.Fatalf("Failed to restart container: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("request method = %q, want %q", gotMethod, http.MethodPost)
+	}
+	if want := "/v1.47/containers/replica-1/restart?t=0"; gotURI != want {
+		t.Errorf("request URI = %q, want %q", gotURI, want)
+	}
+	if err := c.ContainerRestart(t.Context(), "replica-2", container.StopOptions{}); !container.IsNotFound(err) {
+		t.Errorf("ContainerRestart() of missing container = %v, want not found error", err)
+	}
+	if err := c.ContainerRestart(t.Context(), " ", container.StopOptions{}); err == nil {
+		t.Error("ContainerRestart() with empty container ID: expected error")
+	}
+}
+
+func TestContainerKill(t *testing.T) {
+	var gotURI string
+	running := true
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		if !running {
+			http.Error(w, `{"message":"container is not running"}`, http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if err := c.ContainerKill(t.Context(), "replica-1", "SIGHUP"); err != nil {
+		t.Fatalf("Failed to kill container: %v", err)
+	}
+	if want := "/v1.47/containers/replica-1/kill?signal=SIGHUP"; gotURI != want {
+		t.Errorf("request URI = %q, want %q", gotURI, want)
+	}
+	if err := c.ContainerKill(t.Context(), "replica-1", ""); err != nil {
+		t.Fatalf("Failed to kill container: %v", err)
+	}
+	if want := "/v1.47/containers/replica-1/kill"; gotURI != want {
+		t.Errorf("request URI = %q, want %q", gotURI, want)
+	}
+
+	running = false
+	if err := c.ContainerKill(t.Context(), "replica-1", ""); !container.IsConflict(err) {
+		t.Errorf("ContainerKill() of stopped container = %v, want conflict error", err)
+	}
+	if err := c.ContainerKill(t.Context(), "", "SIGKILL"); err == nil {
+		t.Error("ContainerKill() with empty container ID: expected error")
+	}
+}
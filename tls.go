@@ -0,0 +1,71 @@
+package container
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// EnvOverrideCertPath is the name of the environment variable that can be
+	// used to specify the directory from which to load the TLS certificates
+	// (ca.pem, cert.pem, key.pem) used to connect to the docker daemon.
+	EnvOverrideCertPath = "DOCKER_CERT_PATH"
+
+	// EnvTLSVerify is the name of the environment variable that can be used
+	// to enable or disable TLS certificate verification. When set to a
+	// non-empty value, the server certificate is verified against ca.pem,
+	// and ~/.docker is used as the certificate directory if
+	// DOCKER_CERT_PATH is unset.
+	EnvTLSVerify = "DOCKER_TLS_VERIFY"
+)
+
+// tlsConfigFromEnv returns the TLS client configuration described by the
+// DOCKER_CERT_PATH and DOCKER_TLS_VERIFY environment variables.
+// It returns nil if neither variable is set, meaning TLS should not be used.
+func tlsConfigFromEnv() (*tls.Config, error) {
+	certPath := os.Getenv(EnvOverrideCertPath)
+	verify := os.Getenv(EnvTLSVerify) != ""
+	if certPath == "" {
+		if !verify {
+			return nil, nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		certPath = filepath.Join(home, ".docker")
+	}
+	return loadTLSConfig(
+		filepath.Join(certPath, "ca.pem"),
+		filepath.Join(certPath, "cert.pem"),
+		filepath.Join(certPath, "key.pem"),
+		!verify,
+	)
+}
+
+// loadTLSConfig returns a TLS client configuration that trusts the CA
+// certificate in caFile and authenticates with the key pair in certFile
+// and keyFile.
+func loadTLSConfig(caFile, certFile, keyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed to append CA certificate from %s", caFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load X509 key pair: %w", err)
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            pool,
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: insecureSkipVerify,
+	}, nil
+}
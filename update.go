@@ -0,0 +1,13 @@
+package container
+
+// The struct definitions in this file are largely copied from the Docker API
+// types, but may have been simplified for our use case.
+// See the original [UpdateResponse].
+//
+// [UpdateResponse]: https://github.com/moby/moby/blob/master/api/types/container/update_response.go
+
+// UpdateResponse is the response returned from the server when updating a container.
+type UpdateResponse struct {
+	// Warnings holds any non-fatal warnings the daemon reported for the update.
+	Warnings []string `json:"Warnings"`
+}
@@ -0,0 +1,40 @@
+package container_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/relab/container"
+)
+
+func TestContainerUpdate(t *testing.T) {
+	var got container.Resources
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1.47/containers/replica-1/update" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode update request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"Warnings":["Your kernel does not support swap limit capabilities."]}`))
+	})
+
+	resources := container.Resources{Memory: 128 << 20, NanoCPUs: 250_000_000}
+	resp, err := c.ContainerUpdate(t.Context(), "replica-1", resources)
+	if err != nil {
+		t.Fatalf("Failed to update container: %v", err)
+	}
+	if got.Memory != resources.Memory || got.NanoCPUs != resources.NanoCPUs || got.PidsLimit != nil {
+		t.Errorf("update request = %+v, want %+v", got, resources)
+	}
+	if want := []string{"Your kernel does not support swap limit capabilities."}; !slices.Equal(resp.Warnings, want) {
+		t.Errorf("Warnings = %q, want %q", resp.Warnings, want)
+	}
+
+	if _, err := c.ContainerUpdate(t.Context(), " ", resources); err == nil {
+		t.Error("ContainerUpdate() with empty container ID: expected error")
+	}
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultAPIVersion is the most recent API version supported by this package.
+// It is used as the upper bound when negotiating the API version with the daemon.
+const DefaultAPIVersion = "1.51"
+
+// fallbackAPIVersion is the API version assumed if the daemon does not report
+// its API version in the ping response; it predates the API-Version header.
+const fallbackAPIVersion = "1.24"
+
+// ClientVersion returns the API version used to prefix request paths.
+// This is either the version pinned with [WithAPIVersion], the version
+// negotiated with the daemon, or DefaultAPIVersion if negotiation has not
+// yet taken place.
+func (c *Container) ClientVersion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// NegotiateAPIVersion pings the daemon to negotiate the API version to use,
+// which is the lower of DefaultAPIVersion and the daemon's API version.
+// Negotiation happens automatically before the first request is sent, so
+// calling this method is only needed to negotiate eagerly. It has no effect
+// if the API version is pinned or has already been negotiated.
+func (c *Container) NegotiateAPIVersion(ctx context.Context) error {
+	_, err := c.Ping(ctx)
+	return err
+}
+
+// negotiatedVersion returns the API version to use for requests, pinging
+// the daemon to negotiate the version if that has not been done yet.
+func (c *Container) negotiatedVersion(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	version, negotiated := c.version, c.negotiated
+	c.mu.Unlock()
+	if negotiated {
+		return version, nil
+	}
+	if _, err := c.Ping(ctx); err != nil {
+		return "", fmt.Errorf("API version negotiation failed: %w", err)
+	}
+	return c.ClientVersion(), nil
+}
+
+// negotiate downgrades the client's API version to serverVersion if the
+// daemon does not support the client's version. It has no effect if the
+// version is pinned or has already been negotiated.
+func (c *Container) negotiate(serverVersion string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.negotiated {
+		return
+	}
+	if !validVersion(serverVersion) {
+		serverVersion = fallbackAPIVersion
+	}
+	if versionLess(serverVersion, c.version) {
+		c.version = serverVersion
+	}
+	c.negotiated = true
+}
+
+// validVersion reports whether version has the form "<major>.<minor>".
+func validVersion(version string) bool {
+	_, _, err := parseVersion(version)
+	return err == nil
+}
+
+// versionLess reports whether version a is less than version b.
+// Both versions must be valid.
+func versionLess(a, b string) bool {
+	aMajor, aMinor, _ := parseVersion(a)
+	bMajor, bMinor, _ := parseVersion(b)
+	if aMajor != bMajor {
+		return aMajor < bMajor
+	}
+	return aMinor < bMinor
+}
+
+func parseVersion(version string) (major, minor uint64, err error) {
+	majStr, minStr, ok := strings.Cut(version, ".")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid API version %q", version)
+	}
+	if major, err = strconv.ParseUint(majStr, 10, 32); err != nil {
+		return 0, 0, fmt.Errorf("invalid API version %q", version)
+	}
+	if minor, err = strconv.ParseUint(minStr, 10, 32); err != nil {
+		return 0, 0, fmt.Errorf("invalid API version %q", version)
+	}
+	return major, minor, nil
+}